@@ -0,0 +1,554 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const copyPackage = "github.com/gotidy/copy"
+
+// Groups of the builtin types that are converted to each other, as in the funcs package.
+var basicGroups = [][]string{
+	{
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+	},
+	{"float32", "float64"},
+	{"bool"},
+	{"complex64", "complex128"},
+	{"string", "[]byte"},
+}
+
+// SQL null types, their value fields and groups of the types they are converted to/from.
+var nullTypes = map[string]struct {
+	Field string
+	Group []string
+}{
+	"NullInt32":   {Field: "Int32", Group: basicGroups[0]},
+	"NullInt64":   {Field: "Int64", Group: basicGroups[0]},
+	"NullFloat64": {Field: "Float64", Group: basicGroups[1]},
+	"NullBool":    {Field: "Bool", Group: basicGroups[2]},
+	"NullString":  {Field: "String", Group: basicGroups[4]},
+	"NullTime":    {Field: "Time", Group: []string{"time.Time"}},
+}
+
+type typePair struct {
+	Dst *types.Named
+	Src *types.Named
+}
+
+type copyFunc struct {
+	Name string
+	typePair
+}
+
+type generator struct {
+	pkg  *types.Package
+	tag  string
+	skip bool
+
+	imports map[string]string // Path -> name.
+	funcs   []*copyFunc
+	pairs   map[typePair]*copyFunc
+}
+
+func newGenerator(pkg *types.Package, tag string, skip bool) *generator {
+	return &generator{
+		pkg:     pkg,
+		tag:     tag,
+		skip:    skip,
+		imports: make(map[string]string),
+		pairs:   make(map[typePair]*copyFunc),
+	}
+}
+
+// Generate returns the formatted source of the copy functions for the directives.
+func (g *generator) Generate(directives []directive, register bool) ([]byte, error) {
+	for _, d := range directives {
+		dst, err := g.lookup(d.Dst)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Pos, err)
+		}
+		src, err := g.lookup(d.Src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Pos, err)
+		}
+		name := d.Func
+		if name == "" {
+			name = "Copy" + src.Obj().Name() + "To" + dst.Obj().Name()
+		}
+		if _, ok := g.pairs[typePair{Dst: dst, Src: src}]; ok {
+			return nil, fmt.Errorf("%s: duplicated pair %s and %s", d.Pos, d.Dst, d.Src)
+		}
+		g.addFunc(name, dst, src)
+	}
+	exported := len(g.funcs)
+
+	var body bytes.Buffer
+	// Nested functions are appended while generating.
+	for i := 0; i < len(g.funcs); i++ {
+		if err := g.genFunc(&body, g.funcs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if register {
+		g.imports[copyPackage] = "copy"
+		body.WriteString("func init() {\n")
+		for _, f := range g.funcs[:exported] {
+			fmt.Fprintf(&body, "copy.Register(%s)\n", f.Name)
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by copygen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg.Name())
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		buf.WriteString("import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&buf, "%q\n", path)
+		}
+		buf.WriteString(")\n\n")
+	}
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+func (g *generator) lookup(name string) (*types.Named, error) {
+	obj, ok := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not found", name)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a named type", name)
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("type %s is not a struct", name)
+	}
+	return named, nil
+}
+
+func (g *generator) addFunc(name string, dst, src *types.Named) *copyFunc {
+	f := &copyFunc{Name: name, typePair: typePair{Dst: dst, Src: src}}
+	g.funcs = append(g.funcs, f)
+	g.pairs[f.typePair] = f
+	return f
+}
+
+// funcFor returns the name of the function copying src to dst, the function is added if it does not exist.
+func (g *generator) funcFor(dst, src *types.Named) string {
+	if f, ok := g.pairs[typePair{Dst: dst, Src: src}]; ok {
+		return f.Name
+	}
+	return g.addFunc("copy"+src.Obj().Name()+"To"+dst.Obj().Name(), dst, src).Name
+}
+
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// field is a struct field info as internal/cache.Field, but with the expression to access it.
+type field struct {
+	Name string
+	Expr string
+	Type types.Type
+
+	OmitEmpty bool
+	Required  bool
+	ReadOnly  bool
+	WriteOnly bool
+	// Unsupported is the tag option, that is not supported by the generator.
+	Unsupported string
+	// Unknown is the tag option, that is not recognized, for example misspelled.
+	Unknown string
+}
+
+// fields returns fields by the same rules as internal/cache.NewStruct.
+func (g *generator) fields(t *types.Struct) []field {
+	var fields []field
+
+	var traverse func(t *types.Struct, expr string)
+	traverse = func(t *types.Struct, expr string) {
+		for i := 0; i < t.NumFields(); i++ {
+			v := t.Field(i)
+			if !v.Exported() {
+				continue
+			}
+
+			_, isStruct := v.Type().Underlying().(*types.Struct)
+			f := field{Name: v.Name(), Expr: expr + v.Name(), Type: v.Type()}
+			expand := v.Anonymous() && isStruct
+
+			if tag, ok := reflect.StructTag(t.Tag(i)).Lookup(g.tag); ok {
+				if idx := strings.Index(tag, ","); idx != -1 {
+					for _, option := range strings.Split(tag[idx+1:], ",") {
+						switch {
+						case option == "omitempty":
+							f.OmitEmpty = true
+						case option == "required":
+							f.Required = true
+						case option == "readonly":
+							f.ReadOnly = true
+						case option == "writeonly":
+							f.WriteOnly = true
+						case strings.HasPrefix(option, "default="), strings.HasPrefix(option, "layout="), strings.HasPrefix(option, "tz="):
+							f.Unsupported = option
+						case option != "":
+							f.Unknown = option
+						}
+					}
+					tag = tag[:idx]
+				}
+				switch tag {
+				case "-":
+					continue
+				case "+":
+					expand = isStruct
+					tag = ""
+				}
+				if tag != "" {
+					f.Name = tag
+				}
+			}
+
+			fields = append(fields, f)
+
+			if expand {
+				traverse(v.Type().Underlying().(*types.Struct), f.Expr+".")
+			}
+		}
+	}
+	traverse(t, "")
+
+	return fields
+}
+
+func (g *generator) genFunc(w *bytes.Buffer, f *copyFunc) error {
+	srcFields := g.fields(f.Src.Underlying().(*types.Struct))
+	dstList := g.fields(f.Dst.Underlying().(*types.Struct))
+	dstFields := make(map[string]field)
+	for _, field := range dstList {
+		dstFields[field.Name] = field
+	}
+
+	// Dotted paths and some tag options are supported by the copiers only, the generated function would differ.
+	for _, fields := range [][]field{srcFields, dstList} {
+		for _, field := range fields {
+			if strings.Contains(field.Name, ".") {
+				return fmt.Errorf("%s: field «%s»: dotted paths are not supported", f.Name, field.Name)
+			}
+			if field.Unsupported != "" {
+				return fmt.Errorf("%s: field «%s»: the tag option «%s» is not supported", f.Name, field.Name, field.Unsupported)
+			}
+			if field.Unknown != "" {
+				return fmt.Errorf("%s: field «%s»: the tag option «%s» is unknown", f.Name, field.Name, field.Unknown)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "// %s copies %s to %s.\n", f.Name, f.Src.Obj().Name(), f.Dst.Obj().Name())
+	fmt.Fprintf(w, "func %s(dst *%s, src *%s) {\n", f.Name, g.typeString(f.Dst), g.typeString(f.Src))
+	matched := make(map[string]bool)
+	for _, srcField := range srcFields {
+		dstField, ok := dstFields[srcField.Name]
+		if !ok || srcField.WriteOnly || dstField.ReadOnly {
+			continue
+		}
+		matched[dstField.Name] = true
+		code, err := g.assign("dst."+dstField.Expr, "src."+srcField.Expr, dstField.Type, srcField.Type, 0)
+		if err != nil {
+			if g.skip {
+				continue
+			}
+			return fmt.Errorf("%s: field «%s»: %w", f.Name, dstField.Name, err)
+		}
+		if srcField.OmitEmpty || dstField.OmitEmpty {
+			cond, err := g.notEmpty("src."+srcField.Expr, srcField.Type)
+			if err != nil {
+				return fmt.Errorf("%s: field «%s»: %w", f.Name, dstField.Name, err)
+			}
+			code = "if " + cond + " {\n" + code + "}\n"
+		}
+		w.WriteString(code)
+	}
+	w.WriteString("}\n\n")
+
+	for _, dstField := range dstList {
+		if dstField.Required && !matched[dstField.Name] && !dstField.ReadOnly {
+			return fmt.Errorf("%s: required field «%s» has no source field", f.Name, dstField.Name)
+		}
+	}
+
+	return nil
+}
+
+// notEmpty returns the condition that the value is not empty as the omitempty tag option checks it:
+// nil pointers, interfaces, empty strings, slices and maps, and other zero values are empty.
+func (g *generator) notEmpty(expr string, t types.Type) (string, error) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return expr + ` != ""`, nil
+		case u.Info()&types.IsBoolean != 0:
+			return expr, nil
+		case u.Info()&types.IsNumeric != 0:
+			return expr + " != 0", nil
+		}
+	case *types.Pointer, *types.Interface, *types.Chan, *types.Signature:
+		return expr + " != nil", nil
+	case *types.Slice, *types.Map:
+		return "len(" + expr + ") != 0", nil
+	case *types.Struct, *types.Array:
+		if types.Comparable(t) {
+			return expr + " != (" + g.typeString(t) + "{})", nil
+		}
+	}
+
+	return "", fmt.Errorf("the omitempty tag option is not supported for %s", g.typeString(t))
+}
+
+// basicName returns the name of the builtin type as it is used in basicGroups.
+func basicName(t types.Type) string {
+	switch t := t.(type) {
+	case *types.Basic:
+		switch t.Kind() {
+		case types.Byte:
+			return "uint8"
+		case types.Rune:
+			return "int32"
+		}
+		return t.Name()
+	case *types.Slice:
+		if b, ok := t.Elem().(*types.Basic); ok && b.Kind() == types.Byte {
+			return "[]byte"
+		}
+	case *types.Named:
+		if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" {
+			return "time." + obj.Name()
+		}
+	}
+	return ""
+}
+
+func inGroup(group []string, name string) bool {
+	for _, s := range group {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// convertible reports whether builtin types are converted to each other.
+func convertible(dst, src string) bool {
+	if dst == "" || src == "" {
+		return false
+	}
+	for _, group := range basicGroups {
+		if inGroup(group, dst) && inGroup(group, src) {
+			return true
+		}
+	}
+	return false
+}
+
+// nullName returns the name of the sql null type.
+func nullName(t types.Type) string {
+	if t, ok := t.(*types.Named); ok {
+		if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "database/sql" {
+			if _, ok := nullTypes[obj.Name()]; ok {
+				return obj.Name()
+			}
+		}
+	}
+	return ""
+}
+
+// namedStruct returns the named struct type or nil.
+func namedStruct(t types.Type) *types.Named {
+	if t, ok := t.(*types.Named); ok {
+		if _, ok := t.Underlying().(*types.Struct); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+func deref(expr string) string {
+	return "(*" + expr + ")"
+}
+
+func addr(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[2 : len(expr)-1]
+	}
+	return "&" + expr
+}
+
+// assign returns the code that assigns src expression of the type st to dst expression of the type dt.
+func (g *generator) assign(dst, src string, dt, st types.Type, depth int) (string, error) {
+	if types.Identical(dt, st) {
+		return fmt.Sprintf("%s = %s\n", dst, src), nil
+	}
+
+	if code, ok := g.assignBasic(dst, src, dt, st); ok {
+		return code, nil
+	}
+
+	dp, dIsPtr := dt.(*types.Pointer)
+	sp, sIsPtr := st.(*types.Pointer)
+
+	switch {
+	case dIsPtr && sIsPtr:
+		code, err := g.assign(deref(dst), deref(src), dp.Elem(), sp.Elem(), depth)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("if %s != nil {\nif %s == nil {\n%s = new(%s)\n}\n%s}\n", src, dst, dst, g.typeString(dp.Elem()), code), nil
+	case dIsPtr:
+		code, err := g.assign(deref(dst), src, dp.Elem(), st, depth)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("if %s == nil {\n%s = new(%s)\n}\n%s", dst, dst, g.typeString(dp.Elem()), code), nil
+	case sIsPtr:
+		code, err := g.assign(dst, deref(src), dt, sp.Elem(), depth)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("if %s != nil {\n%s}\n", src, code), nil
+	}
+
+	if ds, ss := namedStruct(dt), namedStruct(st); ds != nil && ss != nil {
+		return fmt.Sprintf("%s(%s, %s)\n", g.funcFor(ds, ss), addr(dst), addr(src)), nil
+	}
+
+	switch du := dt.Underlying().(type) {
+	case *types.Slice:
+		su, ok := st.Underlying().(*types.Slice)
+		if !ok {
+			break
+		}
+		i := fmt.Sprintf("i%d", depth)
+		code, err := g.assign(dst+"["+i+"]", src+"["+i+"]", du.Elem(), su.Elem(), depth+1)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("if %s == nil {\n%s = nil\n} else {\n%s = make(%s, len(%s))\nfor %s := range %s {\n%s}\n}\n",
+			src, dst, dst, g.typeString(dt), src, i, src, code), nil
+	case *types.Map:
+		su, ok := st.Underlying().(*types.Map)
+		if !ok {
+			break
+		}
+		k, v := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+		dk, dv := fmt.Sprintf("dk%d", depth), fmt.Sprintf("dv%d", depth)
+		keyCode, err := g.assign(dk, k, du.Key(), su.Key(), depth+1)
+		if err != nil {
+			return "", err
+		}
+		elemCode, err := g.assign(dv, v, du.Elem(), su.Elem(), depth+1)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("if %s == nil {\n%s = nil\n} else {\n%s = make(%s, len(%s))\nfor %s, %s := range %s {\nvar %s %s\nvar %s %s\n%s%s%s[%s] = %s\n}\n}\n",
+			src, dst, dst, g.typeString(dt), src, k, v, src,
+			dk, g.typeString(du.Key()), dv, g.typeString(du.Elem()), keyCode, elemCode, dst, dk, dv), nil
+	}
+
+	return "", fmt.Errorf("the combination of destination(%s) and source(%s) types is not supported", dt, st)
+}
+
+// assignBasic returns the code for the conversions of builtin and sql null types, as the funcs package does.
+func (g *generator) assignBasic(dst, src string, dt, st types.Type) (string, bool) {
+	dp, dIsPtr := dt.(*types.Pointer)
+	sp, sIsPtr := st.(*types.Pointer)
+
+	dName, sName := basicName(dt), basicName(st)
+	if dIsPtr {
+		dName = basicName(dp.Elem())
+	}
+	if sIsPtr {
+		sName = basicName(sp.Elem())
+	}
+
+	if convertible(dName, sName) {
+		dType := g.typeString(dt)
+		if dIsPtr {
+			dType = g.typeString(dp.Elem())
+		}
+		switch {
+		case dIsPtr && sIsPtr:
+			return fmt.Sprintf("if %s == nil {\n%s = nil\n} else if %s != nil {\n*%s = %s(*%s)\n} else {\nv := %s(*%s)\n%s = &v\n}\n",
+				src, dst, dst, dst, dType, src, dType, src, dst), true
+		case dIsPtr:
+			return fmt.Sprintf("if %s != nil {\n*%s = %s(%s)\n} else {\nv := %s(%s)\n%s = &v\n}\n",
+				dst, dst, dType, src, dType, src, dst), true
+		case sIsPtr:
+			return fmt.Sprintf("{\nvar v %s\nif %s != nil {\nv = %s(*%s)\n}\n%s = v\n}\n",
+				dType, src, dType, src, dst), true
+		}
+		return fmt.Sprintf("%s = %s(%s)\n", dst, dType, src), true
+	}
+
+	// sql.Null<Type> -> <type>, *<type>
+	if null := nullName(st); null != "" && inGroup(nullTypes[null].Group, dName) {
+		field := nullTypes[null].Field
+		if dIsPtr {
+			dType := g.typeString(dp.Elem())
+			return fmt.Sprintf("if !%s.Valid {\n%s = nil\n} else if %s != nil {\n*%s = %s(%s.%s)\n} else {\nv := %s(%s.%s)\n%s = &v\n}\n",
+				src, dst, dst, dst, dType, src, field, dType, src, field, dst), true
+		}
+		return fmt.Sprintf("%s = %s(%s.%s)\n", dst, g.typeString(dt), src, field), true
+	}
+
+	// <type>, *<type> -> sql.Null<Type>
+	if null := nullName(dt); null != "" && inGroup(nullTypes[null].Group, sName) {
+		field := nullTypes[null].Field
+		fieldType := g.typeString(nullFieldType(dt, field))
+		if sIsPtr {
+			return fmt.Sprintf("if %s != nil {\n%s = %s{%s: %s(*%s), Valid: true}\n} else {\n%s = %s{}\n}\n",
+				src, dst, g.typeString(dt), field, fieldType, src, dst, g.typeString(dt)), true
+		}
+		return fmt.Sprintf("%s = %s{%s: %s(%s), Valid: true}\n", dst, g.typeString(dt), field, fieldType, src), true
+	}
+
+	return "", false
+}
+
+func nullFieldType(t types.Type, name string) types.Type {
+	s := t.Underlying().(*types.Struct)
+	for i := 0; i < s.NumFields(); i++ {
+		if s.Field(i).Name() == name {
+			return s.Field(i).Type()
+		}
+	}
+	return nil
+}
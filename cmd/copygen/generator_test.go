@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	exampleDir    = "testdata/example"
+	exampleOutput = "testdata/example/copy_gen.go"
+)
+
+func TestGenerate(t *testing.T) {
+	pkg, directives, err := load(exampleDir, exampleOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := newGenerator(pkg, "copy", false).Generate(directives, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile(exampleOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, golden) {
+		t.Errorf("generated code differs from %s:\n%s", exampleOutput, src)
+	}
+}
+
+// TestGenerated checks that the generated code compiles.
+func TestGenerated(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, exampleDir, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var files []*ast.File
+	for _, file := range pkgs["example"].Files {
+		files = append(files, file)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("example", fset, files, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerate_Unsupported(t *testing.T) {
+	pkg, directives, err := load(exampleDir, exampleOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	directives = append(directives, directive{Dst: "Contact", Src: "User"})
+
+	_, err = newGenerator(pkg, "copy", false).Generate(directives, false)
+	if err == nil || !strings.Contains(err.Error(), "«Phone»") {
+		t.Errorf("want error for the field «Phone» got %v", err)
+	}
+
+	if _, err := newGenerator(pkg, "copy", true).Generate(directives, false); err != nil {
+		t.Errorf("unexpected error with skip: %s", err)
+	}
+}
+
+func TestGenerate_TagOptions(t *testing.T) {
+	pkg, directives, err := load(exampleDir, exampleOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		directive directive
+		want      string
+	}{
+		{directive: directive{Dst: "Employee", Src: "Address"}, want: "required field «Email»"},
+		{directive: directive{Dst: "Settings", Src: "User"}, want: "«default=en» is not supported"},
+		{directive: directive{Dst: "Location", Src: "User"}, want: "dotted paths are not supported"},
+		{directive: directive{Dst: "User", Src: "Location"}, want: "dotted paths are not supported"},
+	}
+	for _, tt := range tests {
+		_, err := newGenerator(pkg, "copy", true).Generate(append(directives[:len(directives):len(directives)], tt.directive), false)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s %s: want error %q got %v", tt.directive.Dst, tt.directive.Src, tt.want, err)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	write := func(src string) string {
+		dir := t.TempDir()
+		if err := os.WriteFile(dir+"/types.go", []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	// References to the functions to be generated are allowed.
+	dir := write(`package example
+
+//copy:gen A B
+//copy:gen A C ToA
+
+type A struct{ V int }
+type B struct{ V int }
+type C struct{ V int }
+
+var _, _ = CopyBToA, ToA
+`)
+	if _, _, err := load(dir, dir+"/copy_gen.go"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	dir = write(`package example
+
+//copy:gen A B
+
+type A struct{ V Unknown }
+type B struct{ V int }
+
+var _ = CopyCToA
+`)
+	_, _, err := load(dir, dir+"/copy_gen.go")
+	if err == nil || !strings.Contains(err.Error(), "undefined: Unknown") || !strings.Contains(err.Error(), "undefined: CopyCToA") {
+		t.Errorf("want type checking errors got %v", err)
+	}
+}
+
+func TestGenerate_UnknownOptions(t *testing.T) {
+	dir := t.TempDir()
+	src := `package example
+
+//copy:gen A B
+
+type A struct {
+	V int ` + "`copy:\",requried\"`" + `
+}
+type B struct{ V int }
+`
+	if err := os.WriteFile(dir+"/types.go", []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, directives, err := load(dir, dir+"/copy_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = newGenerator(pkg, "copy", false).Generate(directives, false)
+	if err == nil || !strings.Contains(err.Error(), "«requried» is unknown") {
+		t.Errorf("want the error of the unknown option got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const directivePrefix = "//copy:gen "
+
+// directive is a pair of types to generate a copy function for.
+type directive struct {
+	Dst  string
+	Src  string
+	Func string
+	Pos  token.Position
+}
+
+// load parses and type checks the package in the directory and returns its copy directives.
+// The output file is excluded, because it can be out of date.
+func load(dir, output string) (*types.Package, []directive, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && name != filepath.Base(output)
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			files = append(files, file)
+		}
+	}
+
+	var directives []directive
+	for _, file := range files {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if !strings.HasPrefix(comment.Text, directivePrefix) {
+					continue
+				}
+				pos := fset.Position(comment.Pos())
+				args := strings.Fields(strings.TrimPrefix(comment.Text, directivePrefix))
+				if len(args) < 2 || len(args) > 3 {
+					return nil, nil, fmt.Errorf("%s: expected «//copy:gen Dst Src [Func]»", pos)
+				}
+				d := directive{Dst: args[0], Src: args[1], Pos: pos}
+				if len(args) == 3 {
+					d.Func = args[2]
+				}
+				directives = append(directives, d)
+			}
+		}
+	}
+
+	// The package can refer to the functions that are not generated yet, other errors are reported.
+	generated := make(map[string]bool, len(directives))
+	for _, d := range directives {
+		name := d.Func
+		if name == "" {
+			name = "Copy" + d.Src + "To" + d.Dst
+		}
+		generated[name] = true
+	}
+
+	var errs []string
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error: func(err error) {
+			if e, ok := err.(types.Error); ok && generated[strings.TrimPrefix(e.Msg, "undefined: ")] {
+				return
+			}
+			errs = append(errs, err.Error())
+		},
+	}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("type checking: %s", strings.Join(errs, "\n"))
+	}
+
+	return pkg, directives, nil
+}
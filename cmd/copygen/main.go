@@ -0,0 +1,64 @@
+// Copygen generates plain Go functions copying structs of different types.
+//
+// Pairs of types are declared by directives in the package sources:
+//
+//	//copy:gen Employee User
+//	//copy:gen Employee User CopyUser
+//
+// The first type is the destination, the second one is the source, the optional third
+// argument is the name of the generated function, by default it is Copy<Src>To<Dst>.
+// Fields are matched by the same rules as github.com/gotidy/copy does. The omitempty, required, readonly
+// and writeonly tag options are respected, types with dotted paths and other tag options are refused.
+//
+// Usage:
+//
+//	//go:generate go run github.com/gotidy/copy/cmd/copygen -register
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var (
+		dir      = flag.String("dir", ".", "directory of the package")
+		output   = flag.String("output", "copy_gen.go", "output file name, relative to the package directory")
+		tag      = flag.String("tag", "copy", "struct tag name")
+		skip     = flag.Bool("skip", false, "skip nonassignable fields instead of failing")
+		register = flag.Bool("register", false, "register generated functions by copy.Register in init")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("copygen: ")
+
+	// Registered functions replace the copiers with the «copy» tag, so they must copy all fields the same way.
+	if *register && *skip {
+		log.Fatal("-register can not be used with -skip, skipped fields would not be copied")
+	}
+	if *register && *tag != "copy" {
+		log.Fatal("-register can be used with the «copy» tag only")
+	}
+
+	path := filepath.Join(*dir, *output)
+
+	pkg, directives, err := load(*dir, path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(directives) == 0 {
+		log.Fatalf("no //copy:gen directives found in %s", *dir)
+	}
+
+	g := newGenerator(pkg, *tag, *skip)
+	src, err := g.Generate(directives, *register)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
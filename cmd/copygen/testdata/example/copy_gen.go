@@ -0,0 +1,81 @@
+// Code generated by copygen; DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/gotidy/copy"
+)
+
+// CopyUserToEmployee copies User to Employee.
+func CopyUserToEmployee(dst *Employee, src *User) {
+	dst.Name = src.Person.Name
+	{
+		var v string
+		if src.Person.MiddleName != nil {
+			v = string(*src.Person.MiddleName)
+		}
+		dst.MiddleName = v
+	}
+	dst.Surname = src.Person.Surname
+	if src.Email != "" {
+		dst.Email = src.Email
+	}
+	if !src.Phone.Valid {
+		dst.Phone = nil
+	} else if dst.Phone != nil {
+		*dst.Phone = string(src.Phone.String)
+	} else {
+		v := string(src.Phone.String)
+		dst.Phone = &v
+	}
+}
+
+// ToDTO copies User to UserDTO.
+func ToDTO(dst *UserDTO, src *User) {
+	dst.FirstName = src.Person.Name
+	dst.Email = []byte(src.Email)
+	if dst.Age != nil {
+		*dst.Age = int(src.Age)
+	} else {
+		v := int(src.Age)
+		dst.Age = &v
+	}
+	dst.Created = src.Created
+	if dst.Address == nil {
+		dst.Address = new(AddressDTO)
+	}
+	copyAddressToAddressDTO(dst.Address, &src.Address)
+	if src.Previous == nil {
+		dst.Previous = nil
+	} else {
+		dst.Previous = make([]AddressDTO, len(src.Previous))
+		for i0 := range src.Previous {
+			if src.Previous[i0] != nil {
+				copyAddressToAddressDTO(&dst.Previous[i0], src.Previous[i0])
+			}
+		}
+	}
+	if src.Tags == nil {
+		dst.Tags = nil
+	} else {
+		dst.Tags = make(map[string]int64, len(src.Tags))
+		for k0, v0 := range src.Tags {
+			var dk0 string
+			var dv0 int64
+			dk0 = k0
+			dv0 = int64(v0)
+			dst.Tags[dk0] = dv0
+		}
+	}
+}
+
+// copyAddressToAddressDTO copies Address to AddressDTO.
+func copyAddressToAddressDTO(dst *AddressDTO, src *Address) {
+	dst.City = src.City
+	dst.Zip = int64(src.Zip)
+}
+
+func init() {
+	copy.Register(CopyUserToEmployee)
+	copy.Register(ToDTO)
+}
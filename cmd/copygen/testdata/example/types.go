@@ -0,0 +1,81 @@
+package example
+
+import (
+	"database/sql"
+	"time"
+)
+
+//copy:gen Employee User
+//copy:gen UserDTO User ToDTO
+
+// Person data.
+type Person struct {
+	Name       string
+	MiddleName *string
+	Surname    string
+}
+
+// Address data.
+type Address struct {
+	City string
+	Zip  int
+}
+
+// AddressDTO data.
+type AddressDTO struct {
+	City string
+	Zip  int64
+}
+
+// User data.
+type User struct {
+	Person
+	Email    string
+	Age      int8
+	Married  bool
+	Password string `copy:"-"`
+	Phone    sql.NullString
+	Created  time.Time
+	Address  Address
+	Previous []*Address
+	Tags     map[string]int
+	Token    string `copy:",writeonly"`
+}
+
+// Employee data.
+type Employee struct {
+	Name       string
+	MiddleName string
+	Surname    string
+	Email      string `copy:",omitempty,required"`
+	Age        int    `copy:",readonly"`
+	Password   string
+	Phone      *string
+	Token      string
+}
+
+// UserDTO data.
+type UserDTO struct {
+	FirstName string `copy:"Name"`
+	Email     []byte
+	Age       *int
+	Created   time.Time
+	Address   *AddressDTO
+	Previous  []AddressDTO
+	Tags      map[string]int64
+}
+
+// Contact data, it can not be copied from User.
+type Contact struct {
+	Phone int
+}
+
+// Settings data, default values are not supported by the generator.
+type Settings struct {
+	Lang string `copy:",default=en"`
+}
+
+// Location data, dotted paths are not supported by the generator.
+type Location struct {
+	City string `copy:"Address.City"`
+}
@@ -0,0 +1,109 @@
+package copy
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/gotidy/copy/funcs"
+)
+
+type converterFunc struct {
+	dst, src reflect.Type
+	// name describes the conversion in the plan, for example «main.Money -> string».
+	name string
+	f    func(dst, src unsafe.Pointer)
+}
+
+func newConverterFunc(dst, src reflect.Type, f func(dst, src unsafe.Pointer)) converterFunc {
+	return converterFunc{dst: dst, src: src, name: src.String() + " -> " + dst.String(), f: f}
+}
+
+// converters returns the option adding the converters to the Copiers.
+func converters(c []converterFunc) Option {
+	return func(o *Options) {
+		o.funcs = append(o.funcs, c...)
+	}
+}
+
+// register sets the converters to the default copy functions.
+func register(c []converterFunc) {
+	for _, c := range c {
+		funcs.SetNamed(c.dst, c.src, c.name, c.f)
+	}
+}
+
+// converterFuncs wraps the conversion function into the copy functions for the values
+// and the pointers to values of the Dst and Src types.
+// A nil source pointer gives the zero value or the nil pointer, a conversion error is thrown.
+func converterFuncs[Dst, Src any](f func(Src) (Dst, error)) []converterFunc {
+	convert := func(src Src) Dst {
+		dst, err := f(src)
+		if err != nil {
+			throw(err)
+		}
+		return dst
+	}
+
+	dst := reflect.TypeOf((*Dst)(nil)).Elem()
+	src := reflect.TypeOf((*Src)(nil)).Elem()
+
+	return []converterFunc{
+		// Src to Dst
+		newConverterFunc(dst, src, func(dst, src unsafe.Pointer) {
+			*(*Dst)(dst) = convert(*(*Src)(src))
+		}),
+		// *Src to Dst
+		newConverterFunc(dst, reflect.PtrTo(src), func(dst, src unsafe.Pointer) {
+			var v Dst
+			if p := *(**Src)(src); p != nil {
+				v = convert(*p)
+			}
+			*(*Dst)(dst) = v
+		}),
+		// Src to *Dst
+		newConverterFunc(reflect.PtrTo(dst), src, func(dst, src unsafe.Pointer) {
+			v := convert(*(*Src)(src))
+			p := (**Dst)(dst)
+			if p := *p; p != nil {
+				*p = v
+				return
+			}
+			*p = &v
+		}),
+		// *Src to *Dst
+		newConverterFunc(reflect.PtrTo(dst), reflect.PtrTo(src), func(dst, src unsafe.Pointer) {
+			pSrc := (**Src)(src)
+			pDst := (**Dst)(dst)
+			if *pSrc == nil {
+				*pDst = nil
+				return
+			}
+			v := convert(**pSrc)
+			if p := *pDst; p != nil {
+				*p = v
+				return
+			}
+			*pDst = &v
+		}),
+	}
+}
+
+// Converter sets the function converting values of the Src type to values of the Dst type for the Copiers.
+// Pointers to the Src and Dst types are converted too. The error returned by the function is returned by CopyE.
+//
+//	c := copy.New(copy.Converter(func(src Money) (string, error) {
+//		return src.String(), nil
+//	}))
+func Converter[Dst, Src any](f func(Src) (Dst, error)) Option {
+	return converters(converterFuncs(f))
+}
+
+// RegisterConverter registers the function converting values of the Src type to values of the Dst type
+// in the default copy functions, so it is used by all Copiers. Use the Converter option to limit it to the Copiers.
+//
+//	copy.RegisterConverter(func(src Money) (string, error) {
+//		return src.String(), nil
+//	})
+func RegisterConverter[Dst, Src any](f func(Src) (Dst, error)) {
+	register(converterFuncs(f))
+}
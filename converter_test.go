@@ -0,0 +1,95 @@
+package copy
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gotidy/ptr"
+)
+
+type testMoney struct {
+	Units int64
+	Cents int8
+}
+
+func (m testMoney) String() string {
+	return fmt.Sprintf("%d.%02d", m.Units, m.Cents)
+}
+
+func TestConverter(t *testing.T) {
+	type testStruct1 struct {
+		V  testMoney
+		P  *testMoney
+		PV *testMoney
+		VP testMoney
+	}
+	type testStruct2 struct {
+		V  string
+		P  *string
+		PV string
+		VP *string
+	}
+
+	errNegative := errors.New("negative")
+	c := New(Converter(func(src testMoney) (string, error) {
+		if src.Units < 0 {
+			return "", errNegative
+		}
+		return src.String(), nil
+	}))
+
+	src := testStruct1{V: testMoney{Units: 10, Cents: 5}, VP: testMoney{Units: 1}}
+	dst := testStruct2{P: ptr.String("not nil"), PV: "not empty"}
+	c.Copy(&dst, &src)
+	equal(t, dst, testStruct2{V: "10.05", VP: ptr.String("1.00")})
+
+	src.P = &testMoney{Units: 2}
+	src.PV = &testMoney{Units: 3}
+	c.Copy(&dst, &src)
+	equal(t, dst, testStruct2{V: "10.05", P: ptr.String("2.00"), PV: "3.00", VP: ptr.String("1.00")})
+
+	src.V.Units = -1
+	err := c.CopyE(&dst, &src)
+	if !errors.Is(err, errNegative) {
+		t.Fatalf("want %v, got %v", errNegative, err)
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "V" {
+		t.Errorf("want the error of the field «V», got %v", err)
+	}
+
+	if err := New().PrepareE(&dst, &src); err == nil {
+		t.Error("the converter must not be visible to other copiers")
+	}
+
+	converters := map[string]string{}
+	for _, f := range c.Explain(&dst, &src).Fields {
+		converters[f.Dst] = f.Converter
+	}
+	equal(t, converters, map[string]string{
+		"V":  "copy.testMoney -> string",
+		"P":  "*copy.testMoney -> *string",
+		"PV": "*copy.testMoney -> string",
+		"VP": "copy.testMoney -> *string",
+	})
+}
+
+func TestRegisterConverter(t *testing.T) {
+	type testKind int
+	type testStruct1 struct {
+		Kind testKind
+	}
+	type testStruct2 struct {
+		Kind *string
+	}
+
+	RegisterConverter(func(src testKind) (string, error) {
+		return fmt.Sprintf("kind %d", src), nil
+	})
+
+	src := testStruct1{Kind: 2}
+	dst := testStruct2{}
+	New().Copy(&dst, &src)
+	equal(t, dst, testStruct2{Kind: ptr.String("kind 2")})
+}
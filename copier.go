@@ -1,10 +1,10 @@
 package copy
 
 import (
+	"fmt"
 	"reflect"
+	"sync"
 	"unsafe"
-
-	"github.com/gotidy/copy/funcs"
 )
 
 type TypeInfo struct {
@@ -24,8 +24,12 @@ func (t TypeInfo) Check(typ Type) bool {
 type BaseCopier struct {
 	*Copiers
 
-	dst TypeInfo
-	src TypeInfo
+	dst     TypeInfo
+	src     TypeInfo
+	dstType reflect.Type
+	srcType reflect.Type
+
+	throwing bool // Copying can throw an error.
 }
 
 func NewBaseCopier(c *Copiers) BaseCopier {
@@ -35,14 +39,85 @@ func NewBaseCopier(c *Copiers) BaseCopier {
 func (b *BaseCopier) init(dst, src reflect.Type) {
 	b.dst = NewTypeInfo(dst)
 	b.src = NewTypeInfo(src)
+	b.dstType = dst
+	b.srcType = src
+	// The copier of cyclic types is used before its initialization is finished.
+	b.throwing = true
 }
 
-func (b *BaseCopier) getCopierFunc(dst, src reflect.Type, dstOffset, srcOffset uintptr) copierFunc {
-	copierFunc := funcs.Get(dst, src)
+// throws reports whether copying can throw an error.
+func (b *BaseCopier) throws() bool {
+	return b.throwing
+}
+
+// check checks types of the destination and the source and returns pointers to their data.
+func (b *BaseCopier) check(dst, src interface{}) (dstPtr, srcPtr unsafe.Pointer, err error) {
+	dstType, dstPtr := DataOf(dst)
+	srcType, srcPtr := DataOf(src)
+
+	if b.src.Check(srcType) {
+		return nil, nil, &TypeMismatchError{Arg: "source", Expected: b.src.Name, Actual: typeName(src)}
+	}
+	if b.dst.Check(dstType) {
+		return nil, nil, &TypeMismatchError{Arg: "destination", Expected: b.dst.Name, Actual: typeName(dst)}
+	}
+	if srcPtr == nil {
+		return nil, nil, fmt.Errorf("source %w", ErrNilPointer)
+	}
+	if dstPtr == nil {
+		return nil, nil, fmt.Errorf("destination %w", ErrNilPointer)
+	}
+
+	return dstPtr, srcPtr, nil
+}
+
+// getCopierFunc returns the function that copies the src type value at srcOffset to the dst type value at dstOffset
+// and reports whether the function can throw an error.
+// If the types combination is not supported then nil is returned without the error.
+func (b *BaseCopier) getCopierFunc(dst, src reflect.Type, dstOffset, srcOffset uintptr) (copierFunc, bool, error) {
+	f, _, _, throws, err := b.planCopierFunc(dst, src, dstOffset, srcOffset)
+	return f, throws, err
+}
+
+// planCopierFunc is getCopierFunc that also returns the chosen strategy and the name of the used function or copier.
+func (b *BaseCopier) planCopierFunc(dst, src reflect.Type, dstOffset, srcOffset uintptr) (copierFunc, Strategy, string, bool, error) {
+	if b.options.Deep && sameLayout(dst, src) && needsDeepCopy(src) {
+		f, err := b.deepCopierFunc(dst, src, dstOffset, srcOffset)
+		return f, DeepStrategy, "", true, err
+	}
+
+	// Structs with hooks are copied by the struct copier, that calls them.
+	// Unexported fields are not visible to the struct copier, so the memory is copied as is before.
+	if dst == src && dst.Kind() == reflect.Struct && hasHooks(dst) {
+		copier, strategy, name, throws, err := b.nestedCopierFunc(dst, src, dstOffset, srcOffset)
+		if copier == nil || !hasUnexportedFields(src) {
+			return copier, strategy, name, throws, err
+		}
+		size := int(src.Size())
+		return func(dstPtr, srcPtr unsafe.Pointer) {
+			memcopy(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset), size)
+			copier(dstPtr, srcPtr)
+		}, strategy, name, throws, err
+	}
+
+	// encoding.TextMarshaler -> string or []byte, string or []byte -> encoding.TextUnmarshaler.
+	// Functions set for the pair of types take precedence, but not the generic functions of the underlying types.
+	if textFunc := textFunc(dst, src); textFunc != nil && b.lookupFunc(dst, src) == nil {
+		return func(dstPtr, srcPtr unsafe.Pointer) {
+			textFunc(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+		}, TextStrategy, "", true, nil
+	}
+
+	copierFunc, name := b.getFunc(dst, src)
 	if copierFunc != nil {
+		// Named functions are converters, that throw conversion errors.
+		throws := name != ""
+		if !throws {
+			name = funcName(copierFunc)
+		}
 		return func(dstPtr, srcPtr unsafe.Pointer) {
 			copierFunc(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
-		}
+		}, FuncStrategy, name, throws, nil
 	}
 
 	// same type -> same type
@@ -55,24 +130,85 @@ func (b *BaseCopier) getCopierFunc(dst, src reflect.Type, dstOffset, srcOffset u
 			// dst := reflect.NewAt(dst, unsafe.Pointer(uintptr(dstPtr)+dst.Offset)).Elem()
 			// dst.Set(src)
 			memcopy(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset), size)
-		}
+		}, MemcopyStrategy, "", false, nil
 	}
 
-	copier, err := b.get(dst, src)
-	if err == nil {
+	// interface -> concrete type, the copier is chosen by the dynamic type of the source value.
+	if src.Kind() == reflect.Interface && dst.Kind() != reflect.Interface {
+		copierFunc := b.dynamicCopierFunc(dst, src)
 		return func(dstPtr, srcPtr unsafe.Pointer) {
-			copier.copy(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+			copierFunc(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+		}, DynamicStrategy, "", true, nil
+	}
+
+	return b.nestedCopierFunc(dst, src, dstOffset, srcOffset)
+}
+
+// nestedCopierFunc returns the function that copies values by the copier for the pair of types.
+func (b *BaseCopier) nestedCopierFunc(dst, src reflect.Type, dstOffset, srcOffset uintptr) (copierFunc, Strategy, string, bool, error) {
+	copier, err := b.get(dst, src)
+	if err != nil {
+		if _, ok := err.(*UnsupportedTypesError); ok {
+			return nil, SkippedStrategy, "", false, nil
 		}
+		return nil, SkippedStrategy, "", false, err
 	}
 
-	return nil
+	return func(dstPtr, srcPtr unsafe.Pointer) {
+		copier.copy(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+	}, CopierStrategy, fmt.Sprintf("%T", copier), copier.throws(), nil
+}
+
+// dynamicCopierFunc returns the function that copies a value stored in the interface of type src to dst.
+// Copiers are created lazily for each met dynamic type.
+func (b *BaseCopier) dynamicCopierFunc(dst, src reflect.Type) copierFunc {
+	var copiers sync.Map // map[reflect.Type]copierFunc
+
+	return func(dstPtr, srcPtr unsafe.Pointer) {
+		value := reflect.NewAt(src, srcPtr).Elem()
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+		typ := value.Type()
+
+		f, ok := copiers.Load(typ)
+		if !ok {
+			var err error
+			if f, err = b.lockedCopierFunc(dst, typ); err != nil {
+				throw(err)
+			}
+			copiers.Store(typ, f)
+		}
+		copierFunc := f.(copierFunc)
+		if copierFunc == nil {
+			if !b.options.Skip {
+				throw(&UnsupportedTypesError{Dst: dst, Src: typ})
+			}
+			return
+		}
+
+		// The value stored in the interface is not addressable, so copy it.
+		ptr := reflect.New(typ)
+		ptr.Elem().Set(value)
+		copierFunc(dstPtr, unsafe.Pointer(ptr.Pointer()))
+	}
+}
+
+// lockedCopierFunc is getCopierFunc that can be called while copying.
+func (b *BaseCopier) lockedCopierFunc(dst, src reflect.Type) (copierFunc, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	f, _, err := b.getCopierFunc(dst, src, 0, 0)
+	return f, err
 }
 
 type ValueToPValueCopier struct {
 	BaseCopier
 
 	structCopier func(dst, src unsafe.Pointer)
-	size         int
+	alloc        allocator
 }
 
 func NewValueToPValueCopier(c *Copiers) *ValueToPValueCopier {
@@ -80,31 +216,31 @@ func NewValueToPValueCopier(c *Copiers) *ValueToPValueCopier {
 	return copier
 }
 
-func (c *ValueToPValueCopier) init(dst, src reflect.Type) {
+func (c *ValueToPValueCopier) init(dst, src reflect.Type) error {
 	c.BaseCopier.init(dst, src)
-	dst = dst.Elem()                                // *struct -> struct
-	c.structCopier = checkGet(c.get(dst, src)).copy // Get struct copier for struct -> struct
-	c.size = int(dst.Size())
+	dst = dst.Elem()               // *struct -> struct
+	copier, err := c.get(dst, src) // Get struct copier for struct -> struct
+	if err != nil {
+		return err
+	}
+	c.structCopier = copier.copy
+	c.throwing = copier.throws()
+	c.alloc = newAllocator(dst)
+	return nil
 }
 
 func (c *ValueToPValueCopier) Copy(dst, src interface{}) {
-	dstType, dstPtr := DataOf(dst)
-	srcType, srcPtr := DataOf(src)
-
-	if c.src.Check(srcType) {
-		panic("source expected type " + c.src.Name + ", but has " + reflect.TypeOf(src).String())
-	}
-	if c.dst.Check(dstType) {
-		panic("destination expected type " + c.dst.Name + ", but has " + reflect.TypeOf(dst).String())
-	}
+	mustCopy(c, dst, src)
+}
 
-	c.copy(dstPtr, srcPtr)
+func (c *ValueToPValueCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
 }
 
 func (c *ValueToPValueCopier) copy(dst, src unsafe.Pointer) {
 	dstFieldPtr := (**struct{})(dst)
 	if *dstFieldPtr == nil {
-		*dstFieldPtr = (*struct{})(alloc(c.size))
+		*dstFieldPtr = (*struct{})(c.alloc.New())
 	}
 
 	c.structCopier(unsafe.Pointer(*dstFieldPtr), src)
@@ -121,24 +257,24 @@ func NewPValueToValueCopier(c *Copiers) *PValueToValueCopier {
 	return copier
 }
 
-func (c *PValueToValueCopier) init(dst, src reflect.Type) {
+func (c *PValueToValueCopier) init(dst, src reflect.Type) error {
 	c.BaseCopier.init(dst, src)
-	src = src.Elem()                                // *struct -> struct
-	c.structCopier = checkGet(c.get(dst, src)).copy // Get struct copier for struct -> struct
+	src = src.Elem()               // *struct -> struct
+	copier, err := c.get(dst, src) // Get struct copier for struct -> struct
+	if err != nil {
+		return err
+	}
+	c.structCopier = copier.copy
+	c.throwing = copier.throws()
+	return nil
 }
 
 func (c *PValueToValueCopier) Copy(dst, src interface{}) {
-	dstType, dstPtr := DataOf(dst)
-	srcType, srcPtr := DataOf(src)
-
-	if c.src.Check(srcType) {
-		panic("source expected type " + c.src.Name + ", but has " + reflect.TypeOf(src).String())
-	}
-	if c.dst.Check(dstType) {
-		panic("destination expected type " + c.dst.Name + ", but has " + reflect.TypeOf(dst).String())
-	}
+	mustCopy(c, dst, src)
+}
 
-	c.copy(dstPtr, srcPtr)
+func (c *PValueToValueCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
 }
 
 func (c *PValueToValueCopier) copy(dst, src unsafe.Pointer) {
@@ -154,7 +290,7 @@ type PValueToPValueCopier struct {
 	BaseCopier
 
 	structCopier func(dst, src unsafe.Pointer)
-	size         int
+	alloc        allocator
 }
 
 func NewPValueToPValueCopier(c *Copiers) *PValueToPValueCopier {
@@ -162,26 +298,26 @@ func NewPValueToPValueCopier(c *Copiers) *PValueToPValueCopier {
 	return copier
 }
 
-func (c *PValueToPValueCopier) init(dst, src reflect.Type) {
+func (c *PValueToPValueCopier) init(dst, src reflect.Type) error {
 	c.BaseCopier.init(dst, src)
-	dst = dst.Elem()                                // *struct -> struct
-	src = src.Elem()                                // *struct -> struct
-	c.structCopier = checkGet(c.get(dst, src)).copy // Get struct copier for struct -> struct
-	c.size = int(dst.Size())
+	dst = dst.Elem()               // *struct -> struct
+	src = src.Elem()               // *struct -> struct
+	copier, err := c.get(dst, src) // Get struct copier for struct -> struct
+	if err != nil {
+		return err
+	}
+	c.structCopier = copier.copy
+	c.throwing = copier.throws()
+	c.alloc = newAllocator(dst)
+	return nil
 }
 
 func (c *PValueToPValueCopier) Copy(dst, src interface{}) {
-	dstType, dstPtr := DataOf(dst)
-	srcType, srcPtr := DataOf(src)
-
-	if c.src.Check(srcType) {
-		panic("source expected type " + c.src.Name + ", but has " + reflect.TypeOf(src).String())
-	}
-	if c.dst.Check(dstType) {
-		panic("destination expected type " + c.dst.Name + ", but has " + reflect.TypeOf(dst).String())
-	}
+	mustCopy(c, dst, src)
+}
 
-	c.copy(dstPtr, srcPtr)
+func (c *PValueToPValueCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
 }
 
 func (c *PValueToPValueCopier) copy(dst, src unsafe.Pointer) {
@@ -192,7 +328,7 @@ func (c *PValueToPValueCopier) copy(dst, src unsafe.Pointer) {
 
 	dstFieldPtr := (**struct{})(dst)
 	if *dstFieldPtr == nil {
-		*dstFieldPtr = (*struct{})(alloc(c.size))
+		*dstFieldPtr = (*struct{})(c.alloc.New())
 	}
 
 	c.structCopier(unsafe.Pointer(*dstFieldPtr), unsafe.Pointer(*srcFieldPtr))
@@ -69,3 +69,11 @@ func BenchmarkCopiers(b *testing.B) {
 		c.Copy(&dst, &src)
 	}
 }
+
+func BenchmarkTypedCopier(b *testing.B) {
+	copier := For[testStruct, testStruct](New())
+
+	for i := 0; i < b.N; i++ {
+		copier.Copy(&dst, &src)
+	}
+}
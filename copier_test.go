@@ -2,8 +2,15 @@ package copy
 
 import (
 	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
 	"sync"
 	"testing"
+	"unsafe"
+
+	"github.com/gotidy/copy/funcs"
+	"github.com/gotidy/ptr"
 )
 
 func trim(b []rune) []rune {
@@ -575,3 +582,526 @@ func TestCopier_Slice(t *testing.T) {
 	New().Get(&dst, &src).Copy(&dst, &src)
 	equal(t, dst, src)
 }
+
+func TestCopier_Map(t *testing.T) {
+	type internal1 struct {
+		I int
+		S string
+	}
+
+	type internal2 struct {
+		I int64
+		S string
+	}
+
+	type testStruct1 struct {
+		M1 map[string]int
+		M2 map[string]*internal1
+		M3 map[int]internal1
+		M4 *map[string]int
+		M5 map[string]int
+	}
+
+	type testStruct2 struct {
+		M1 map[string]int64
+		M2 map[string]internal2
+		M3 map[int64]*internal2
+		M4 map[string]int
+		M5 map[string]*int
+	}
+
+	m4 := map[string]int{"a": 1}
+	src := testStruct1{
+		M1: map[string]int{"a": 1, "b": 2},
+		M2: map[string]*internal1{"a": {I: 1, S: "1"}, "b": {I: 2, S: "2"}},
+		M3: map[int]internal1{1: {I: 1, S: "1"}, 2: {I: 2, S: "2"}},
+		M4: &m4,
+		M5: map[string]int{"a": 1, "b": 2},
+	}
+	dst := testStruct2{}
+
+	New().Get(&dst, &src).Copy(&dst, &src)
+	equal(t, dst, src)
+
+	if dst.M5["a"] == dst.M5["b"] {
+		t.Error("map elements must not share the pointers")
+	}
+
+	src.M1 = nil
+	New().Get(&dst, &src).Copy(&dst, &src)
+	if dst.M1 != nil {
+		t.Errorf("want nil map got %v", dst.M1)
+	}
+}
+
+func TestCopier_StructToMap(t *testing.T) {
+	type Address struct {
+		City string
+	}
+
+	type Order struct {
+		ID int
+	}
+
+	type User struct {
+		Name    string `copy:"name"`
+		Age     int
+		Address Address
+		Home    *Address
+		Work    *Address
+		Orders  []Order
+		Tags    []string
+	}
+
+	src := User{
+		Name:    "John",
+		Age:     33,
+		Address: Address{City: "Boston"},
+		Home:    &Address{City: "Austin"},
+		Orders:  []Order{{ID: 1}, {ID: 2}},
+		Tags:    []string{"a", "b"},
+	}
+	dst := map[string]interface{}{}
+
+	Copy(&dst, &src)
+	expected := map[string]interface{}{
+		"name":    "John",
+		"Age":     33,
+		"Address": map[string]interface{}{"City": "Boston"},
+		"Home":    map[string]interface{}{"City": "Austin"},
+		"Work":    nil,
+		"Orders":  []interface{}{map[string]interface{}{"ID": 1}, map[string]interface{}{"ID": 2}},
+		"Tags":    []string{"a", "b"},
+	}
+	equal(t, dst, expected)
+	if _, ok := dst["Address"].(map[string]interface{}); !ok {
+		t.Errorf("nested struct must be converted to map, but has %T", dst["Address"])
+	}
+
+	back := User{}
+	Copy(&back, &dst)
+	equal(t, back, src)
+}
+
+func TestCopier_StructToTypedMap(t *testing.T) {
+	type testStruct struct {
+		A int
+		B int8
+		C *int
+	}
+
+	src := testStruct{A: 1, B: 2}
+	dst := map[string]int64{}
+
+	New().Copy(&dst, &src)
+	equal(t, dst, map[string]int64{"A": 1, "B": 2, "C": 0})
+
+	back := testStruct{}
+	dst["C"] = 3
+	New().Copy(&back, &dst)
+	equal(t, back, testStruct{A: 1, B: 2, C: ptr.Int(3)})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("must panic when fields are not assignable to map elements")
+			}
+		}()
+		New().Get(&map[string]bool{}, &src)
+	}()
+}
+
+func TestCopiers_CopyE(t *testing.T) {
+	type testStruct1 struct {
+		S string
+	}
+	type testStruct2 struct {
+		S int
+	}
+
+	v := testStruct1{S: "string"}
+	c := New()
+
+	if err := c.CopyE(&testStruct1{}, &v); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := c.CopyE(v, &v); !errors.Is(err, ErrNotPointer) {
+		t.Errorf("want ErrNotPointer got %v", err)
+	}
+	if err := c.CopyE(&v, nil); !errors.Is(err, ErrNotPointer) {
+		t.Errorf("want ErrNotPointer got %v", err)
+	}
+	if err := c.CopyE(&testStruct2{}, &v); err == nil {
+		t.Error("must return the error when fields are not assignable")
+	}
+	if err := c.PrepareE(&testStruct2{}, &v); err == nil {
+		t.Error("must return the error when fields are not assignable")
+	}
+	if err := CopyE(new(int), &v); !errors.As(err, new(*UnsupportedTypesError)) {
+		t.Errorf("want UnsupportedTypesError got %v", err)
+	}
+
+	copier, err := c.GetE(&testStruct1{}, &v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var mismatch *TypeMismatchError
+	if err := copier.CopyE(&testStruct2{}, &v); !errors.As(err, &mismatch) || mismatch.Arg != "destination" {
+		t.Errorf("want TypeMismatchError got %v", err)
+	}
+	if err := copier.CopyE(&testStruct1{}, (*testStruct1)(nil)); !errors.Is(err, ErrNilPointer) {
+		t.Errorf("want ErrNilPointer got %v", err)
+	}
+
+	src := map[string]interface{}{"S": 1}
+	if err := c.CopyE(&testStruct1{}, &src); err == nil {
+		t.Error("must return the error when a map element is not assignable")
+	}
+}
+
+func TestCopier_FieldError(t *testing.T) {
+	type Address1 struct {
+		Zip string
+	}
+	type Customer1 struct {
+		Address Address1
+	}
+	type Order1 struct {
+		Customer *Customer1
+	}
+	type testStruct1 struct {
+		Orders []Order1
+	}
+
+	type Address2 struct {
+		Zip int
+	}
+	type Customer2 struct {
+		Address Address2
+	}
+	type Order2 struct {
+		Customer Customer2
+	}
+	type testStruct2 struct {
+		Orders []Order2
+	}
+
+	_, err := New().GetE(&testStruct2{}, &testStruct1{})
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("want FieldError got %v", err)
+	}
+	if fieldErr.Path != "Orders[].Customer.Address.Zip" {
+		t.Errorf("want path «Orders[].Customer.Address.Zip» got «%s»", fieldErr.Path)
+	}
+	if fieldErr.Dst != reflect.TypeOf(0) || fieldErr.Src != reflect.TypeOf("") {
+		t.Errorf("want types int and string got %s and %s", fieldErr.Dst, fieldErr.Src)
+	}
+	if !errors.As(err, new(*UnsupportedTypesError)) {
+		t.Errorf("want UnsupportedTypesError got %v", err)
+	}
+
+	type testStruct3 struct {
+		Items []map[string]interface{}
+	}
+	type item struct {
+		Count int
+	}
+	type testStruct4 struct {
+		Items []item
+	}
+
+	src := testStruct3{Items: []map[string]interface{}{{"Count": "one"}}}
+	err = New().CopyE(&testStruct4{}, &src)
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("want FieldError got %v", err)
+	}
+	if fieldErr.Path != "Items[].Count" {
+		t.Errorf("want path «Items[].Count» got «%s»", fieldErr.Path)
+	}
+
+	// Copy panics with the error, that is inspected by errors.As.
+	for name, copy := range map[string]func(){
+		"Copy": func() { New().Copy(&testStruct4{}, &src) },
+		"For":  func() { For[testStruct4, testStruct3](New()).Copy(&testStruct4{}, &src) },
+		"Get":  func() { New().Get(&testStruct4{}, &src).Copy(&testStruct4{}, &src) },
+	} {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.As(err, &fieldErr) {
+					t.Errorf("%s: want the panic with FieldError got %v", name, err)
+				}
+			}()
+			copy()
+		}()
+	}
+
+	// Errors thrown by nested copiers of cyclic types carry the path too.
+	type node1 struct {
+		Value    testMoney
+		Next     *node1
+		Children map[string]node1
+	}
+	type node2 struct {
+		Value    string
+		Next     *node2
+		Children map[string]node2
+	}
+	c := New(Converter(func(src testMoney) (string, error) {
+		if src.Units < 0 {
+			return "", errors.New("negative")
+		}
+		return src.String(), nil
+	}))
+	node := node1{Next: &node1{Children: map[string]node1{"a": {Value: testMoney{Units: -1}}}}}
+	err = c.CopyE(&node2{}, &node)
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Next.Children[].Value" {
+		t.Errorf("want the error of the field «Next.Children[].Value» got %v", err)
+	}
+}
+
+func TestCopier_Throws(t *testing.T) {
+	type internal struct {
+		I int
+	}
+	type testStruct1 struct {
+		S  string
+		V  internal
+		PV *internal
+		M  testMoney
+	}
+	type testStruct2 struct {
+		S  string
+		V  internal
+		PV *internal
+		M  string
+	}
+
+	if New(Skip()).Get(&testStruct2{}, &testStruct1{}).(internalCopier).throws() {
+		t.Error("copying without converters and hooks must not throw")
+	}
+	c := New(Converter(func(src testMoney) (string, error) { return src.String(), nil }))
+	if !c.Get(&testStruct2{}, &testStruct1{}).(internalCopier).throws() {
+		t.Error("copying by converters must throw")
+	}
+	if !c.Get(&[]testStruct2{}, &[]testStruct1{}).(internalCopier).throws() {
+		t.Error("copying of slices of elements copied by converters must throw")
+	}
+}
+
+func TestCopier_Deep(t *testing.T) {
+	type Ints []int
+
+	type internal struct {
+		I  int
+		PI *int
+	}
+
+	type testStruct struct {
+		I    *int
+		II   []int
+		IIs  Ints
+		PII  []*int
+		M    map[string][]int
+		V    internal
+		PV   *internal
+		A    [2]*int
+		Next *testStruct
+	}
+
+	type testStruct2 struct {
+		I    *int
+		II   []int
+		IIs  []int
+		PII  []*int
+		M    map[string][]int
+		V    internal
+		PV   *internal
+		A    [2]*int
+		Next *testStruct
+	}
+
+	src := testStruct{
+		I:    ptr.Int(1),
+		II:   []int{1, 2},
+		IIs:  Ints{1, 2},
+		PII:  []*int{ptr.Int(1)},
+		M:    map[string][]int{"a": {1}},
+		V:    internal{I: 1, PI: ptr.Int(1)},
+		PV:   &internal{I: 1, PI: ptr.Int(1)},
+		A:    [2]*int{ptr.Int(1)},
+		Next: &testStruct{II: []int{1}},
+	}
+
+	check := func(dst *testStruct2) {
+		t.Helper()
+
+		*dst.I = 10
+		dst.II[0] = 10
+		dst.IIs[0] = 10
+		*dst.PII[0] = 10
+		dst.M["a"][0] = 10
+		*dst.V.PI = 10
+		dst.PV.I = 10
+		*dst.PV.PI = 10
+		*dst.A[0] = 10
+		dst.Next.II[0] = 10
+
+		expected := testStruct{
+			I:    ptr.Int(1),
+			II:   []int{1, 2},
+			IIs:  Ints{1, 2},
+			PII:  []*int{ptr.Int(1)},
+			M:    map[string][]int{"a": {1}},
+			V:    internal{I: 1, PI: ptr.Int(1)},
+			PV:   &internal{I: 1, PI: ptr.Int(1)},
+			A:    [2]*int{ptr.Int(1)},
+			Next: &testStruct{II: []int{1}},
+		}
+		equal(t, src, expected)
+	}
+
+	dst := testStruct2{}
+	New(Deep()).Copy(&dst, &src)
+	check(&dst)
+
+	// Destination shares memory with the source after a shallow copying.
+	dst = testStruct2{}
+	New().Copy(&dst, &src)
+	New(Deep()).Copy(&dst, &src)
+	check(&dst)
+}
+
+func TestRegister(t *testing.T) {
+	type testStruct1 struct {
+		Name string
+	}
+	type testStruct2 struct {
+		FullName string
+	}
+	type testStruct3 struct {
+		V []testStruct1
+	}
+	type testStruct4 struct {
+		V []testStruct2
+	}
+
+	Register(func(dst *testStruct2, src *testStruct1) {
+		dst.FullName = "registered " + src.Name
+	})
+
+	src := testStruct3{V: []testStruct1{{Name: "John"}}}
+	dst := testStruct4{}
+	New(Tag(defaultTagName)).Copy(&dst, &src)
+	equal(t, dst, testStruct4{V: []testStruct2{{FullName: "registered John"}}})
+
+	// Copiers with other tags match fields differently, so they do not use the registered function.
+	dst = testStruct4{}
+	New().Copy(&dst, &src)
+	equal(t, dst, testStruct4{V: []testStruct2{{}}})
+
+	type testStruct5 struct {
+		Name string
+		P    []int
+	}
+	type testStruct6 struct {
+		Name string
+		P    []int
+	}
+
+	Register(func(dst *testStruct6, src *testStruct5) {
+		*dst = testStruct6(*src)
+	})
+
+	src5 := testStruct5{P: []int{1}}
+	dst6 := testStruct6{}
+	Copy(&dst6, &src5)
+	if &dst6.P[0] != &src5.P[0] {
+		t.Error("the registered function must be used by default")
+	}
+
+	dst6 = testStruct6{}
+	New(Tag(defaultTagName), Deep()).Copy(&dst6, &src5)
+	if &dst6.P[0] == &src5.P[0] {
+		t.Error("the registered function must not be used with the Deep option")
+	}
+
+	dst6 = testStruct6{Name: "John"}
+	New(Tag(defaultTagName), IgnoreEmpty()).Copy(&dst6, &src5)
+	if dst6.Name != "John" {
+		t.Error("the registered function must not be used with the IgnoreEmpty option")
+	}
+}
+
+func TestFunc(t *testing.T) {
+	type testStruct1 struct {
+		V int
+	}
+	type testStruct2 struct {
+		V string
+	}
+
+	intToString := func(dst, src unsafe.Pointer) {
+		*(*string)(dst) = strconv.Itoa(*(*int)(src))
+	}
+
+	c := New(Func(reflect.TypeOf(""), reflect.TypeOf(0), intToString))
+	src := testStruct1{V: 10}
+	dst := testStruct2{}
+	c.Copy(&dst, &src)
+	equal(t, dst, testStruct2{V: "10"})
+
+	if err := New().PrepareE(&dst, &src); err == nil {
+		t.Error("the function must not be visible to other copiers")
+	}
+
+	f := funcs.New()
+	c = New(WithFuncs(f), Func(reflect.TypeOf(""), reflect.TypeOf(0), intToString))
+	dst = testStruct2{}
+	c.Copy(&dst, &src)
+	equal(t, dst, testStruct2{V: "10"})
+	if f.Get(reflect.TypeOf(""), reflect.TypeOf(0)) != nil {
+		t.Error("the storage set by WithFuncs must not be changed")
+	}
+
+	f.Set(reflect.TypeOf(""), reflect.TypeOf(0), func(dst, src unsafe.Pointer) {
+		*(*string)(dst) = "custom"
+	})
+	c = New(WithFuncs(f))
+	c.Copy(&dst, &src)
+	equal(t, dst, testStruct2{V: "custom"})
+
+	// Functions set by options are kept regardless of the order of WithFuncs.
+	for _, c := range []*Copiers{
+		New(ParseStrings(), WithFuncs(funcs.New())),
+		New(WithFuncs(funcs.New()), ParseStrings()),
+	} {
+		dst = testStruct2{}
+		c.Copy(&dst, &src)
+		equal(t, dst, testStruct2{V: "10"})
+	}
+}
+
+func TestCopier_NamedTypes(t *testing.T) {
+	type Status string
+	type UserID int64
+	type Celsius float64
+	type testStruct1 struct {
+		Status Status
+		ID     UserID
+		Temp   *Celsius
+		Count  int
+	}
+	type testStruct2 struct {
+		Status *string
+		ID     int64
+		Temp   float32
+		Count  float64
+	}
+
+	temp := Celsius(20.5)
+	dst := testStruct2{}
+	New().Copy(&dst, &testStruct1{Status: "active", ID: 10, Temp: &temp, Count: 3})
+	equal(t, dst, testStruct2{Status: ptr.String("active"), ID: 10, Temp: 20.5, Count: 3})
+}
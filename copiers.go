@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 	"unsafe"
 
+	"github.com/gotidy/copy/funcs"
 	"github.com/gotidy/copy/internal/cache"
 )
 
@@ -26,6 +28,24 @@ type indirectCopierKey struct {
 type Options struct {
 	Tag  string
 	Skip bool
+	Deep bool
+	// Strict requires every destination field to have a source field.
+	Strict bool
+	// IgnoreEmpty does not copy empty source fields.
+	IgnoreEmpty bool
+	// Flatten matches fields with nested fields by concatenated names.
+	Flatten bool
+	// NameMatcher normalizes field names before matching, if it is nil then names must be equal.
+	NameMatcher NameMatcher
+	// Funcs is the storage of copy functions, if it is nil then the default functions are used.
+	Funcs *funcs.CopyFuncs
+	// TimeLayout is the layout of times converted to and from strings and integers, RFC3339 by default.
+	TimeLayout string
+	// TimeLocation is the location of times converted to and from strings and integers.
+	TimeLocation *time.Location
+
+	funcs      []converterFunc
+	transforms map[transformKey]transform
 }
 
 // Option changes default Copiers parameters.
@@ -45,15 +65,104 @@ func Skip() Option {
 	}
 }
 
+// Deep copying, slices, maps, pointers and nested structs of the same types are cloned
+// instead of sharing them between a destination and a source.
+func Deep() Option {
+	return func(o *Options) {
+		o.Deep = true
+	}
+}
+
+// Strict requires every destination struct field to have a source field, otherwise the error listing
+// all unmapped fields is returned. Fields tagged as readonly, with default values and ignored ones are not required.
+func Strict() Option {
+	return func(o *Options) {
+		o.Strict = true
+	}
+}
+
+// IgnoreEmpty does not copy nil pointers, zero values and empty strings, slices and maps of source fields,
+// so destination fields are left untouched. It is useful for merging of a partially filled struct.
+// The «omitempty» tag option sets the same for a field.
+func IgnoreEmpty() Option {
+	return func(o *Options) {
+		o.IgnoreEmpty = true
+	}
+}
+
+// Flatten matches fields with nested fields by concatenated names, when fields with the same name are not found.
+// A destination field «CustomerName» is filled from the source field «Customer.Name» and vice versa.
+// If several paths match, then the path with fewer fields is preferred, then the path with longer leading field names.
+func Flatten() Option {
+	return func(o *Options) {
+		o.Flatten = true
+	}
+}
+
+// MatchNames sets the matcher of field names.
+//
+//	c := copy.New(copy.MatchNames(copy.SnakeCase))
+func MatchNames(m NameMatcher) Option {
+	return func(o *Options) {
+		o.NameMatcher = m
+	}
+}
+
+// WithFuncs sets the storage of copy functions, so custom conversions do not affect other Copiers.
+// Functions set by other options are added to the Copiers layer over the storage, regardless of the order of options.
+//
+//	f := funcs.New()
+//	f.Set(reflect.TypeOf(""), reflect.TypeOf(time.Time{}), copyTimeToString)
+//	c := copy.New(copy.WithFuncs(f))
+func WithFuncs(f *funcs.CopyFuncs) Option {
+	return func(o *Options) {
+		o.Funcs = f
+	}
+}
+
+// Func sets the copy function for the pair of types. The function is visible to the Copiers only,
+// neither the default functions nor the storage set by WithFuncs are changed.
+func Func(dst, src reflect.Type, f func(dst, src unsafe.Pointer)) Option {
+	return func(o *Options) {
+		o.funcs = append(o.funcs, converterFunc{dst: dst, src: src, f: f})
+	}
+}
+
 // StructCopier fills a destination from source.
 type Copier interface {
 	Copy(dst interface{}, src interface{})
+	CopyE(dst interface{}, src interface{}) error
 }
 
 type internalCopier interface {
 	Copier
 	copy(dst, src unsafe.Pointer)
-	init(dst, src reflect.Type)
+	init(dst, src reflect.Type) error
+	throws() bool
+	check(dst, src interface{}) (dstPtr, srcPtr unsafe.Pointer, err error)
+}
+
+// mustCopy checks types of dst and src and copies src into dst, it panics if the types do not match the copier.
+func mustCopy(c internalCopier, dst, src interface{}) {
+	dstPtr, srcPtr, err := c.check(dst, src)
+	if err != nil {
+		panic(err)
+	}
+
+	c.copy(dstPtr, srcPtr)
+}
+
+// copyE checks types of dst and src and copies src into dst.
+func copyE(c internalCopier, dst, src interface{}) (err error) {
+	dstPtr, srcPtr, err := c.check(dst, src)
+	if err != nil {
+		return err
+	}
+
+	defer catch(&err)
+	c.copy(dstPtr, srcPtr)
+
+	return nil
 }
 
 // Copiers is a structs copier.
@@ -64,6 +173,7 @@ type Copiers struct {
 	mu              sync.RWMutex
 	copiers         map[copierKey]internalCopier
 	indirectCopiers map[indirectCopierKey]Copier
+	mappings        map[copierKey]*fieldMapping
 }
 
 // New create new internalCopier.
@@ -74,14 +184,46 @@ func New(options ...Option) *Copiers {
 		option(&opts)
 	}
 
+	// Functions set by options are stored in the own layer over the storage set by WithFuncs or the default functions.
+	if len(opts.funcs) > 0 {
+		if opts.Funcs == nil {
+			opts.Funcs = funcs.New()
+		} else {
+			opts.Funcs = opts.Funcs.Extend()
+		}
+		for _, f := range opts.funcs {
+			opts.Funcs.SetNamed(f.dst, f.src, f.name, f.f)
+		}
+		opts.funcs = nil
+	}
+
 	return &Copiers{
-		cache:           cache.New(opts.Tag),
+		cache:           cache.New(opts.Tag, opts.NameMatcher),
 		options:         opts,
 		copiers:         make(map[copierKey]internalCopier),
 		indirectCopiers: make(map[indirectCopierKey]Copier),
+		mappings:        make(map[copierKey]*fieldMapping),
 	}
 }
 
+// getFunc returns the copy function for the pair of types from the Copiers storage and the name of the function.
+func (c *Copiers) getFunc(dst, src reflect.Type) (func(dst, src unsafe.Pointer), string) {
+	if c.options.Funcs != nil {
+		return c.options.Funcs.GetNamed(dst, src)
+	}
+
+	return funcs.GetNamed(dst, src)
+}
+
+// lookupFunc returns the copy function set for the pair of types to the Copiers storage, without fallbacks.
+func (c *Copiers) lookupFunc(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
+	if c.options.Funcs != nil {
+		return c.options.Funcs.Lookup(dst, src)
+	}
+
+	return funcs.Lookup(dst, src)
+}
+
 // Prepare caches structures of src and dst. Dst and src each must be a pointer to struct.
 // contents is not copied. It can be used for checking ability of copying.
 //
@@ -91,16 +233,25 @@ func (c *Copiers) Prepare(dst, src interface{}) {
 	_ = c.Get(dst, src)
 }
 
+// PrepareE is like Prepare but returns an error instead of panic.
+func (c *Copiers) PrepareE(dst, src interface{}) error {
+	_, err := c.GetE(dst, src)
+	return err
+}
+
 // Copy copies the contents of src into dst. Dst and src each must be a pointer to struct.
 func (c *Copiers) Copy(dst, src interface{}) {
 	c.Get(dst, src).Copy(dst, src)
 }
 
-func checkGet(copier internalCopier, err error) internalCopier {
+// CopyE is like Copy but returns an error instead of panic.
+func (c *Copiers) CopyE(dst, src interface{}) error {
+	copier, err := c.GetE(dst, src)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	return copier
+
+	return copier.CopyE(dst, src)
 }
 
 func (c *Copiers) get(dst, src reflect.Type) (internalCopier, error) {
@@ -109,47 +260,68 @@ func (c *Copiers) get(dst, src reflect.Type) (internalCopier, error) {
 		return copier, nil
 	}
 
-	copier = getCopier(c, dst, src)
+	if f := c.registered(dst, src); f != nil {
+		copier = NewFuncCopier(c, f)
+	} else {
+		copier = getCopier(c, dst, src)
+	}
 	if copier == nil {
-		return nil, fmt.Errorf("the combination of destination(%s) and source(%s) types is not supported", dst, src)
+		return nil, &UnsupportedTypesError{Dst: dst, Src: src}
 	}
 
+	// The copier is cached before initialization to allow cyclic types.
 	c.copiers[copierKey{Src: src, Dest: dst}] = copier
 
-	copier.init(dst, src)
+	if err := copier.init(dst, src); err != nil {
+		delete(c.copiers, copierKey{Src: src, Dest: dst})
+		return nil, err
+	}
 
 	return copier, nil
 }
 
 // Get Copier for a specific destination and source.
 func (c *Copiers) Get(dst, src interface{}) Copier {
+	copier, err := c.GetE(dst, src)
+	if err != nil {
+		panic(err)
+	}
+
+	return copier
+}
+
+// GetE is like Get but returns an error instead of panic.
+func (c *Copiers) GetE(dst, src interface{}) (Copier, error) {
 	c.mu.RLock()
 	copier, ok := c.indirectCopiers[indirectCopierKey{Dest: TypeOf(dst), Src: TypeOf(src)}]
 	c.mu.RUnlock()
 	if ok {
-		return copier
+		return copier, nil
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	srcType := reflect.TypeOf(src)
-	if srcType.Kind() != reflect.Ptr {
-		panic("source must be pointer")
+	if srcType == nil || srcType.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("source %w", ErrNotPointer)
 	}
 	srcType = srcType.Elem()
 
 	dstType := reflect.TypeOf(dst)
-	if dstType.Kind() != reflect.Ptr {
-		panic("destination must be pointer")
+	if dstType == nil || dstType.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("destination %w", ErrNotPointer)
 	}
 	dstType = dstType.Elem()
 
-	copier = checkGet(c.get(dstType, srcType))
+	copier, err := c.get(dstType, srcType)
+	if err != nil {
+		return nil, err
+	}
 
 	c.indirectCopiers[indirectCopierKey{Dest: TypeOf(dst), Src: TypeOf(src)}] = copier
 
-	return copier
+	return copier, nil
 }
 
 // defaultCopier uses Copier with a "copy" tag.
@@ -163,12 +335,27 @@ func Prepare(dst, src interface{}) {
 	defaultCopier.Prepare(dst, src)
 }
 
+// PrepareE is like Prepare but returns an error instead of panic.
+func PrepareE(dst, src interface{}) error {
+	return defaultCopier.PrepareE(dst, src)
+}
+
 // Copy copies the contents of src into dst. Dst and src each must be a pointer to a struct.
 func Copy(dst, src interface{}) {
 	defaultCopier.Copy(dst, src)
 }
 
+// CopyE is like Copy but returns an error instead of panic.
+func CopyE(dst, src interface{}) error {
+	return defaultCopier.CopyE(dst, src)
+}
+
 // Get Copier for a specific destination and source.
 func Get(dst, src interface{}) Copier {
 	return defaultCopier.Get(dst, src)
 }
+
+// GetE is like Get but returns an error instead of panic.
+func GetE(dst, src interface{}) (Copier, error) {
+	return defaultCopier.GetE(dst, src)
+}
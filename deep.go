@@ -0,0 +1,126 @@
+package copy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// needsDeepCopy reports whether a copy of a value of the type shares memory with the original.
+// Strings are immutable, and interfaces, channels and functions can not be cloned, so they are not taken into account.
+func needsDeepCopy(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && needsDeepCopy(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if needsDeepCopy(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameLayout reports whether values of the types are copied by memcopy.
+// It mirrors the rules of funcs.Get for the types with the same elements.
+func sameLayout(dst, src reflect.Type) bool {
+	if dst == src {
+		return true
+	}
+	if dst.Kind() != src.Kind() {
+		return false
+	}
+	switch dst.Kind() {
+	case reflect.Array:
+		return dst.Len() == src.Len() && dst.Elem() == src.Elem()
+	case reflect.Ptr, reflect.Slice:
+		return dst.Elem() == src.Elem()
+	case reflect.Map:
+		return dst.Elem() == src.Elem() && dst.Key() == src.Key()
+	}
+	return false
+}
+
+func hasUnexportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// deepCopierFunc returns the function that recursively clones the src value into the dst value,
+// so they do not share memory.
+func (b *BaseCopier) deepCopierFunc(dst, src reflect.Type, dstOffset, srcOffset uintptr) (copierFunc, error) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		elemCopier, _, err := b.getCopierFunc(dst.Elem(), src.Elem(), 0, 0)
+		if err != nil || elemCopier == nil {
+			return nil, err
+		}
+		alloc := newAllocator(dst.Elem())
+
+		return func(dstPtr, srcPtr unsafe.Pointer) {
+			srcFieldPtr := (*unsafe.Pointer)(unsafe.Pointer(uintptr(srcPtr) + srcOffset))
+			dstFieldPtr := (*unsafe.Pointer)(unsafe.Pointer(uintptr(dstPtr) + dstOffset))
+			if *srcFieldPtr == nil {
+				*dstFieldPtr = nil
+				return
+			}
+			// Destination can point to the source value after a shallow copying.
+			if *dstFieldPtr == nil || *dstFieldPtr == *srcFieldPtr {
+				*dstFieldPtr = alloc.New()
+			}
+			elemCopier(*dstFieldPtr, *srcFieldPtr)
+		}, nil
+
+	case reflect.Array:
+		elemCopier, _, err := b.getCopierFunc(dst.Elem(), src.Elem(), 0, 0)
+		if err != nil || elemCopier == nil {
+			return nil, err
+		}
+		dstSize := dst.Elem().Size()
+		srcSize := src.Elem().Size()
+		n := src.Len()
+
+		return func(dstPtr, srcPtr unsafe.Pointer) {
+			dstPtr = unsafe.Pointer(uintptr(dstPtr) + dstOffset)
+			srcPtr = unsafe.Pointer(uintptr(srcPtr) + srcOffset)
+			for i := 0; i < n; i++ {
+				elemCopier(unsafe.Pointer(uintptr(dstPtr)+dstSize*uintptr(i)), unsafe.Pointer(uintptr(srcPtr)+srcSize*uintptr(i)))
+			}
+		}, nil
+
+	case reflect.Struct:
+		copier, err := b.get(dst, src)
+		if err != nil {
+			return nil, err
+		}
+		// Unexported fields are not visible to the struct copier, so they are copied as is.
+		if hasUnexportedFields(src) {
+			size := int(src.Size())
+			return func(dstPtr, srcPtr unsafe.Pointer) {
+				dstPtr = unsafe.Pointer(uintptr(dstPtr) + dstOffset)
+				srcPtr = unsafe.Pointer(uintptr(srcPtr) + srcOffset)
+				memcopy(dstPtr, srcPtr, size)
+				copier.copy(dstPtr, srcPtr)
+			}, nil
+		}
+		return func(dstPtr, srcPtr unsafe.Pointer) {
+			copier.copy(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+		}, nil
+	}
+
+	// Slices and maps.
+	copier, err := b.get(dst, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(dstPtr, srcPtr unsafe.Pointer) {
+		copier.copy(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+	}, nil
+}
@@ -0,0 +1,96 @@
+package copy
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/gotidy/copy/internal/cache"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parseDefault parses the default value of the type set by the «default=value» tag option.
+func parseDefault(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if t == durationType {
+			var d time.Duration
+			d, err = time.ParseDuration(s)
+			i = int64(d)
+		} else {
+			i, err = strconv.ParseInt(s, 10, t.Bits())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, t.Bits())
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, t.Bits())
+		v.SetFloat(f)
+	default:
+		return reflect.Value{}, &UnsupportedTypesError{Dst: t, Src: reflect.TypeOf(s)}
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("default value %q: %w", s, err)
+	}
+
+	return v, nil
+}
+
+// defaultSetter returns the function setting the default value of the field to the field at ptr.
+// Pointers are set to the new value every time, so they are not shared.
+func defaultSetter(f cache.Field) (func(ptr unsafe.Pointer), error) {
+	if f.Type.Kind() == reflect.Ptr {
+		v, err := parseDefault(f.Type.Elem(), f.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(ptr unsafe.Pointer) {
+			p := reflect.New(f.Type.Elem())
+			p.Elem().Set(v)
+			reflect.NewAt(f.Type, ptr).Elem().Set(p)
+		}, nil
+	}
+
+	v, err := parseDefault(f.Type, f.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ptr unsafe.Pointer) {
+		reflect.NewAt(f.Type, ptr).Elem().Set(v)
+	}, nil
+}
+
+// withDefault wraps the copier function of the field, so the default value is set if the source value is empty.
+func withDefault(f copierFunc, dst, src cache.Field) (copierFunc, error) {
+	set, err := defaultSetter(dst)
+	if err != nil {
+		return nil, err
+	}
+	empty := emptyFunc(src.Type)
+
+	return func(dstPtr, srcPtr unsafe.Pointer) {
+		if empty(unsafe.Pointer(uintptr(srcPtr) + src.Offset)) {
+			set(unsafe.Pointer(uintptr(dstPtr) + dst.Offset))
+			return
+		}
+		f(dstPtr, srcPtr)
+	}, nil
+}
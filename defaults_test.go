@@ -0,0 +1,101 @@
+package copy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestCopier_TagOptions(t *testing.T) {
+	type testStruct1 struct {
+		Name     string
+		Password string `copy:",writeonly"`
+		ID       int
+		Country  string
+		Timeout  *time.Duration
+	}
+	type testStruct2 struct {
+		Name     string `copy:",required"`
+		Password string
+		ID       int           `copy:",readonly"`
+		Country  string        `copy:",default=FR"`
+		Lang     string        `copy:",default=en"`
+		Retries  *int          `copy:",default=3"`
+		Timeout  time.Duration `copy:",default=5s"`
+	}
+
+	c := New(Tag("copy"))
+
+	dst := testStruct2{ID: 1}
+	c.Copy(&dst, &testStruct1{Name: "John", Password: "secret", ID: 2})
+	equal(t, dst, testStruct2{Name: "John", ID: 1, Country: "FR", Lang: "en", Retries: ptr.Int(3), Timeout: 5 * time.Second})
+
+	timeout := time.Second
+	c.Copy(&dst, &testStruct1{Name: "John", Country: "DE", Timeout: &timeout})
+	equal(t, dst, testStruct2{Name: "John", ID: 1, Country: "DE", Lang: "en", Retries: ptr.Int(3), Timeout: time.Second})
+
+	retries := dst.Retries
+	c.Copy(&dst, &testStruct1{})
+	if dst.Retries == retries {
+		t.Error("the default pointer must not be shared")
+	}
+
+	// Password is readable from the second struct.
+	src := testStruct1{}
+	c.Copy(&src, &testStruct2{Name: "John", Password: "secret", ID: 2})
+	equal(t, src, testStruct1{Name: "John", Password: "secret", ID: 2, Timeout: new(time.Duration)})
+}
+
+func TestCopier_TagOptionsErrors(t *testing.T) {
+	type testStruct1 struct {
+		Name string
+	}
+	type testStruct2 struct {
+		Email string `copy:",required"`
+	}
+	type testStruct3 struct {
+		Name int `copy:",default=ten"`
+	}
+	type testStruct4 struct {
+		Name []int `copy:",default=10"`
+	}
+
+	c := New(Tag("copy"))
+
+	var fieldErr *FieldError
+	err := c.PrepareE(&testStruct2{}, &testStruct1{})
+	if !errors.Is(err, ErrRequired) || !errors.As(err, &fieldErr) || fieldErr.Path != "Email" {
+		t.Errorf("want ErrRequired of «Email», got %v", err)
+	}
+
+	if err := c.PrepareE(&testStruct3{}, &testStruct1{}); !errors.As(err, &fieldErr) || fieldErr.Path != "Name" {
+		t.Errorf("want the error of «Name», got %v", err)
+	}
+
+	if err := c.PrepareE(&testStruct4{}, &testStruct1{}); !errors.As(err, new(*UnsupportedTypesError)) {
+		t.Errorf("want UnsupportedTypesError, got %v", err)
+	}
+
+	type testStruct5 struct {
+		Name  string `copy:",requried"`
+		Email string `copy:",readonly "`
+	}
+	for name, prepare := range map[string]func() error{
+		"dst":    func() error { return c.PrepareE(&testStruct5{}, &testStruct1{}) },
+		"src":    func() error { return c.PrepareE(&testStruct1{}, &testStruct5{}) },
+		"to map": func() error { return c.PrepareE(&map[string]interface{}{}, &testStruct5{}) },
+	} {
+		err := prepare()
+		if !errors.Is(err, ErrUnknownOption) || !errors.As(err, &fieldErr) || fieldErr.Path != "Name" || !strings.Contains(err.Error(), "«requried»") {
+			t.Errorf("%s: want ErrUnknownOption of «Name», got %v", name, err)
+		}
+	}
+	if err := c.PrepareE(&testStruct1{}, &struct {
+		Name string `copy:"Name,"`
+	}{}); err != nil {
+		t.Errorf("empty options must be ignored, got %v", err)
+	}
+}
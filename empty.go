@@ -0,0 +1,45 @@
+package copy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// emptyFunc returns the function that reports whether the value of the type at ptr is empty:
+// nil pointers, interfaces, empty strings, slices and maps, and other zero values.
+func emptyFunc(t reflect.Type) func(ptr unsafe.Pointer) bool {
+	switch t.Kind() {
+	case reflect.String:
+		return func(ptr unsafe.Pointer) bool {
+			return len(*(*string)(ptr)) == 0
+		}
+	case reflect.Slice:
+		return func(ptr unsafe.Pointer) bool {
+			return len(*(*[]byte)(ptr)) == 0
+		}
+	case reflect.Map:
+		return func(ptr unsafe.Pointer) bool {
+			return reflect.NewAt(t, ptr).Elem().Len() == 0
+		}
+	case reflect.Struct:
+		return func(ptr unsafe.Pointer) bool {
+			return reflect.NewAt(t, ptr).Elem().IsZero()
+		}
+	default:
+		size := t.Size()
+		return func(ptr unsafe.Pointer) bool {
+			return isZeroAt(ptr, size)
+		}
+	}
+}
+
+// omitEmpty wraps the copier function of the field, so it does not copy the empty source value.
+func omitEmpty(f copierFunc, src reflect.Type, srcOffset uintptr) copierFunc {
+	empty := emptyFunc(src)
+
+	return func(dst, src unsafe.Pointer) {
+		if !empty(unsafe.Pointer(uintptr(src) + srcOffset)) {
+			f(dst, src)
+		}
+	}
+}
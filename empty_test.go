@@ -0,0 +1,60 @@
+package copy
+
+import (
+	"testing"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestCopier_IgnoreEmpty(t *testing.T) {
+	type Inner struct {
+		V int
+	}
+	type testStruct struct {
+		I  int
+		S  string
+		P  *int
+		Sl []int
+		M  map[string]int
+		St Inner
+		If interface{}
+	}
+
+	dst := testStruct{I: 1, S: "s", P: ptr.Int(1), Sl: []int{1}, M: map[string]int{"a": 1}, St: Inner{V: 1}, If: 1}
+	New(IgnoreEmpty()).Copy(&dst, &testStruct{Sl: []int{}, M: map[string]int{}, S: "s"[:0]})
+	equal(t, dst, testStruct{I: 1, S: "s", P: ptr.Int(1), Sl: []int{1}, M: map[string]int{"a": 1}, St: Inner{V: 1}, If: 1})
+
+	New(IgnoreEmpty()).Copy(&dst, &testStruct{I: 2, P: ptr.Int(2), St: Inner{V: 2}})
+	equal(t, dst, testStruct{I: 2, S: "s", P: ptr.Int(2), Sl: []int{1}, M: map[string]int{"a": 1}, St: Inner{V: 2}, If: 1})
+
+	New().Copy(&dst, &testStruct{})
+	equal(t, dst, testStruct{})
+}
+
+func TestCopier_OmitEmptyTag(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type testStruct1 struct {
+		Name    string  `copy:",omitempty"`
+		Email   *string `copy:"Mail,omitempty"`
+		Age     int
+		Address *Address
+	}
+	type testStruct2 struct {
+		Name  string
+		Mail  *string
+		Age   int
+		City  string `copy:"Address.City,omitempty"`
+		Phone string
+	}
+
+	c := New(Tag("copy"))
+
+	dst := testStruct2{Name: "John", Mail: ptr.String("john@example.com"), Age: 33, City: "Paris"}
+	c.Copy(&dst, &testStruct1{})
+	equal(t, dst, testStruct2{Name: "John", Mail: ptr.String("john@example.com"), City: "Paris"})
+
+	c.Copy(&dst, &testStruct1{Name: "Bob", Email: ptr.String("bob@example.com"), Address: &Address{City: "London"}})
+	equal(t, dst, testStruct2{Name: "Bob", Mail: ptr.String("bob@example.com"), City: "London"})
+}
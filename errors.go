@@ -0,0 +1,181 @@
+package copy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+var (
+	// ErrNotPointer is returned when a destination or a source is not a pointer.
+	ErrNotPointer = errors.New("must be pointer")
+	// ErrNilPointer is returned when a destination or a source is a nil pointer.
+	ErrNilPointer = errors.New("must not be nil")
+	// ErrZeroCopier is returned by the zero TypedCopier, that is not created by For or ForE.
+	ErrZeroCopier = errors.New("typed copier is not created by For or ForE")
+	// ErrRequired is returned when a required destination field has no source field.
+	ErrRequired = errors.New("required field has no source field")
+	// ErrUnknownOption is returned when a field has the unknown tag option.
+	ErrUnknownOption = errors.New("unknown tag option")
+	// ErrOverflow is returned in the checked numeric mode when a value does not fit the destination type.
+	ErrOverflow = errors.New("overflows the destination type")
+	// ErrSignLoss is returned in the checked numeric mode when a negative value is converted to an unsigned type.
+	ErrSignLoss = errors.New("loses the sign")
+	// ErrFractionLoss is returned in the checked numeric mode when a float with a fractional part is converted to an integer.
+	ErrFractionLoss = errors.New("loses the fractional part")
+)
+
+// TypeMismatchError is returned when a destination or a source type does not match the copier type.
+type TypeMismatchError struct {
+	Arg      string // "source" or "destination".
+	Expected string
+	Actual   string
+}
+
+func (e *TypeMismatchError) Error() string {
+	return e.Arg + " expected type " + e.Expected + ", but has " + e.Actual
+}
+
+// UnsupportedTypesError is returned when the combination of destination and source types is not supported.
+type UnsupportedTypesError struct {
+	Dst reflect.Type
+	Src reflect.Type
+}
+
+func (e *UnsupportedTypesError) Error() string {
+	return fmt.Sprintf("the combination of destination(%s) and source(%s) types is not supported", e.Dst, e.Src)
+}
+
+// AmbiguousFieldError is returned when a field is matched with several fields of the struct by the name matcher.
+type AmbiguousFieldError struct {
+	Type   reflect.Type
+	Fields []string
+}
+
+func (e *AmbiguousFieldError) Error() string {
+	return fmt.Sprintf("ambiguous fields «%s» of %s", strings.Join(e.Fields, "», «"), e.Type)
+}
+
+// UnmappedFieldsError is returned in the strict mode when destination fields have no source fields.
+type UnmappedFieldsError struct {
+	Dst    reflect.Type
+	Src    reflect.Type
+	Fields []string
+}
+
+func (e *UnmappedFieldsError) Error() string {
+	return fmt.Sprintf("fields «%s» of the destination(%s) have no source fields in the source(%s)", strings.Join(e.Fields, "», «"), e.Dst, e.Src)
+}
+
+// NumericError is returned in the checked numeric mode when a value is changed by the conversion.
+type NumericError struct {
+	Value interface{} // The source value.
+	Dst   reflect.Type
+	// Err is ErrOverflow, ErrSignLoss or ErrFractionLoss.
+	Err error
+}
+
+func (e *NumericError) Error() string {
+	return fmt.Sprintf("%T(%v) converted to %s %s", e.Value, e.Value, e.Dst, e.Err)
+}
+
+func (e *NumericError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError is returned when a field can not be copied.
+type FieldError struct {
+	// Path to the field from the root value, for example «Orders[].Customer.Address.Zip».
+	// Slice and map elements are denoted by «[]».
+	Path string
+	Dst  reflect.Type
+	Src  reflect.Type
+	// Err is the reason.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return "field «" + e.Path + "»: " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// joinPath joins the path segments.
+func joinPath(parent, path string) string {
+	switch {
+	case parent == "":
+		return path
+	case path == "":
+		return parent
+	case strings.HasPrefix(path, "["):
+		return parent + path
+	}
+	return parent + "." + path
+}
+
+// fieldError prepends the field name to the path of the nested field error,
+// or creates the new field error if err is not a field error.
+func fieldError(name string, dst, src reflect.Type, err error) error {
+	if e, ok := err.(*FieldError); ok {
+		return &FieldError{Path: joinPath(name, e.Path), Dst: e.Dst, Src: e.Src, Err: e.Err}
+	}
+	return &FieldError{Path: name, Dst: dst, Src: src, Err: err}
+}
+
+func typeName(i interface{}) string {
+	if i == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(i).String()
+}
+
+// copyError wraps errors occurred while copying, so they can be distinguished from other panics.
+type copyError struct {
+	error
+}
+
+func (e copyError) Unwrap() error {
+	return e.error
+}
+
+// throw aborts copying with the error, the error is returned by CopyE methods.
+func throw(err error) {
+	panic(copyError{err})
+}
+
+// catch recovers copying errors thrown by throw and stores them in err.
+func catch(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(copyError)
+		if !ok {
+			panic(r)
+		}
+		*err = e.error
+	}
+}
+
+// rethrow adds the field name to the path of the thrown copying error and throws it further.
+// Other panics are passed as is.
+func rethrow(r interface{}, name string, dst, src reflect.Type) {
+	if e, ok := r.(copyError); ok {
+		throw(fieldError(name, dst, src, e.error))
+	}
+	panic(r)
+}
+
+// withPath wraps the copier function, that can throw a copying error, so the field name is added to the path of the error.
+// Functions that do not throw are not wrapped, so copying does not pay for recovering.
+func withPath(f copierFunc, name string, dst, src reflect.Type) copierFunc {
+	return func(dstPtr, srcPtr unsafe.Pointer) {
+		defer func() {
+			if r := recover(); r != nil {
+				rethrow(r, name, dst, src)
+			}
+		}()
+		f(dstPtr, srcPtr)
+	}
+}
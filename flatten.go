@@ -0,0 +1,63 @@
+package copy
+
+import (
+	"reflect"
+	"strings"
+)
+
+// flattenPath returns the dotted path to the nested field of the struct type, which names
+// concatenated give the name, for example «Customer.Name» for «CustomerName».
+// If several paths match, then the path with fewer fields is preferred, then the path with longer leading field names.
+func (c *Copiers) flattenPath(t reflect.Type, name string) (string, bool) {
+	var best []string
+	for _, path := range c.flattenPaths(t, name) {
+		if best == nil || precedes(path, best) {
+			best = path
+		}
+	}
+	if len(best) < 2 {
+		return "", false
+	}
+
+	return strings.Join(best, "."), true
+}
+
+// flattenPaths returns all paths to the nested fields of the struct type, which names concatenated give the name.
+func (c *Copiers) flattenPaths(t reflect.Type, name string) [][]string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var paths [][]string
+	for _, f := range c.cache.GetByType(t).Fields {
+		switch {
+		case isPath(f.Name):
+			continue
+		case f.Name == name:
+			paths = append(paths, []string{f.Name})
+		case strings.HasPrefix(name, f.Name):
+			for _, path := range c.flattenPaths(f.Type, name[len(f.Name):]) {
+				paths = append(paths, append([]string{f.Name}, path...))
+			}
+		}
+	}
+
+	return paths
+}
+
+// precedes reports whether the path a takes precedence over the path b.
+func precedes(a, b []string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return len(a[i]) > len(b[i])
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package copy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCopier_Flatten(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type Customer struct {
+		Name    string
+		Address *Address
+	}
+	type Order struct {
+		ID       int
+		Customer Customer
+	}
+	type OrderDTO struct {
+		ID                  int
+		CustomerName        string
+		CustomerAddressCity string
+		Total               int
+	}
+
+	c := New(Flatten())
+
+	dto := OrderDTO{}
+	c.Copy(&dto, &Order{ID: 1, Customer: Customer{Name: "John", Address: &Address{City: "Paris"}}})
+	equal(t, dto, OrderDTO{ID: 1, CustomerName: "John", CustomerAddressCity: "Paris"})
+
+	order := Order{}
+	c.Copy(&order, &OrderDTO{ID: 1, CustomerName: "John", CustomerAddressCity: "Paris"})
+	equal(t, order, Order{ID: 1, Customer: Customer{Name: "John", Address: &Address{City: "Paris"}}})
+
+	// Without the option nested fields are not matched.
+	dto = OrderDTO{}
+	New().Copy(&dto, &Order{ID: 1, Customer: Customer{Name: "John"}})
+	equal(t, dto, OrderDTO{ID: 1})
+}
+
+func TestCopier_FlattenPrecedence(t *testing.T) {
+	type Name struct {
+		First string
+	}
+	type Customer struct {
+		NameFirst string
+		Name      Name
+	}
+	type Src struct {
+		Customer          Customer
+		CustomerName      Name
+		CustomerNameFirst string
+	}
+	type Dst struct {
+		CustomerNameFirst string
+	}
+	type Src2 struct {
+		Customer     Customer
+		CustomerName Name
+	}
+
+	c := New(Flatten())
+
+	// The field with the same name wins.
+	dst := Dst{}
+	c.Copy(&dst, &Src{Customer: Customer{NameFirst: "1", Name: Name{First: "2"}}, CustomerName: Name{First: "3"}, CustomerNameFirst: "4"})
+	equal(t, dst, Dst{CustomerNameFirst: "4"})
+
+	// The path with fewer fields, then with longer leading names wins.
+	dst = Dst{}
+	c.Copy(&dst, &Src2{Customer: Customer{NameFirst: "1", Name: Name{First: "2"}}, CustomerName: Name{First: "3"}})
+	equal(t, dst, Dst{CustomerNameFirst: "3"})
+
+	if path, ok := c.flattenPath(reflect.TypeOf(Src2{}), "CustomerNameFirst"); !ok || path != "CustomerName.First" {
+		t.Errorf("want «CustomerName.First», got %q", path)
+	}
+}
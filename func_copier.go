@@ -0,0 +1,78 @@
+package copy
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+var registry = struct {
+	mu    sync.RWMutex
+	funcs map[copierKey]copierFunc
+}{funcs: make(map[copierKey]copierFunc)}
+
+// Register registers the function copying values of the Src type to values of the Dst type,
+// for example, the function generated by copygen. Copiers with the «copy» tag and the default options use the registered function
+// instead of building the copier for the pair of types. Copiers with options changing the way of copying,
+// mappings or transforms build the copier, so the options are respected.
+//
+//	copy.Register(CopyUserToEmployee)
+func Register[Dst, Src any](f func(dst *Dst, src *Src)) {
+	key := copierKey{Src: reflect.TypeOf((*Src)(nil)).Elem(), Dest: reflect.TypeOf((*Dst)(nil)).Elem()}
+
+	registry.mu.Lock()
+	registry.funcs[key] = func(dst, src unsafe.Pointer) {
+		f((*Dst)(dst), (*Src)(src))
+	}
+	registry.mu.Unlock()
+}
+
+// registered returns the registered function for the pair of types, if the Copiers copies values
+// the same way as the generated functions do: with the «copy» tag, default matching and copy options,
+// without custom functions, mappings, transforms and hooks of the destination type or types reachable from it.
+// Otherwise nil is returned and the copier is built for the pair of types.
+func (c *Copiers) registered(dst, src reflect.Type) copierFunc {
+	o := c.options
+	if o.Tag != defaultTagName || o.Skip || o.Deep || o.Strict || o.IgnoreEmpty || o.Flatten ||
+		o.NameMatcher != nil || o.Funcs != nil || o.TimeLayout != "" || o.TimeLocation != nil ||
+		len(o.transforms) > 0 || len(c.mappings) > 0 || reachesHooks(dst) {
+		return nil
+	}
+
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return registry.funcs[copierKey{Src: src, Dest: dst}]
+}
+
+// FuncCopier copies values by the registered function.
+type FuncCopier struct {
+	BaseCopier
+
+	copier copierFunc
+}
+
+func NewFuncCopier(c *Copiers, f copierFunc) *FuncCopier {
+	copier := &FuncCopier{BaseCopier: NewBaseCopier(c), copier: f}
+	return copier
+}
+
+func (c *FuncCopier) init(dst, src reflect.Type) error {
+	c.BaseCopier.init(dst, src)
+	c.throwing = false
+	return nil
+}
+
+// Copy copies the contents of src into dst.
+func (c *FuncCopier) Copy(dst, src interface{}) {
+	mustCopy(c, dst, src)
+}
+
+// CopyE is like Copy but returns an error instead of panic.
+func (c *FuncCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
+}
+
+func (c *FuncCopier) copy(dst, src unsafe.Pointer) {
+	c.copier(dst, src)
+}
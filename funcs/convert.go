@@ -0,0 +1,224 @@
+package funcs
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// builtinTypes are the builtin types of scalar kinds.
+var builtinTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       typeOf(false),
+	reflect.Int:        typeOf(int(0)),
+	reflect.Int8:       typeOf(int8(0)),
+	reflect.Int16:      typeOf(int16(0)),
+	reflect.Int32:      typeOf(int32(0)),
+	reflect.Int64:      typeOf(int64(0)),
+	reflect.Uint:       typeOf(uint(0)),
+	reflect.Uint8:      typeOf(uint8(0)),
+	reflect.Uint16:     typeOf(uint16(0)),
+	reflect.Uint32:     typeOf(uint32(0)),
+	reflect.Uint64:     typeOf(uint64(0)),
+	reflect.Uintptr:    typeOf(uintptr(0)),
+	reflect.Float32:    typeOf(float32(0)),
+	reflect.Float64:    typeOf(float64(0)),
+	reflect.Complex64:  typeOf(complex64(0)),
+	reflect.Complex128: typeOf(complex128(0)),
+	reflect.String:     typeOf(""),
+}
+
+// builtin returns the builtin type with the same underlying type as the scalar type
+// or the pointer to such type, other types are returned as is.
+func builtin(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		if b, ok := builtinTypes[t.Elem().Kind()]; ok && b != t.Elem() {
+			return reflect.PtrTo(b)
+		}
+		return t
+	}
+	if b, ok := builtinTypes[t.Kind()]; ok {
+		return b
+	}
+
+	return t
+}
+
+// convertFunc returns the copy function for the integers and floats, that are converted to each other.
+// If the types are not supported, then nil is returned.
+func (t *CopyFuncs) convertFunc(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
+	dstElem, dstPtr := elem(dst)
+	srcElem, srcPtr := elem(src)
+	if !srcElem.ConvertibleTo(dstElem) {
+		return nil
+	}
+	f := numericFunc(dstElem.Kind(), srcElem.Kind())
+	if f == nil {
+		return nil
+	}
+
+	switch {
+	case !dstPtr && !srcPtr:
+		return f
+	case !dstPtr && srcPtr:
+		return func(dst, src unsafe.Pointer) {
+			p := *(*unsafe.Pointer)(src)
+			if p == nil {
+				p = unsafe.Pointer(&zero)
+			}
+			f(dst, p)
+		}
+	case dstPtr && !srcPtr:
+		return func(dst, src unsafe.Pointer) {
+			p := (*unsafe.Pointer)(dst)
+			if *p == nil {
+				*p = reflect.New(dstElem).UnsafePointer()
+			}
+			f(*p, src)
+		}
+	default:
+		return func(dst, src unsafe.Pointer) {
+			p := (*unsafe.Pointer)(dst)
+			pSrc := *(*unsafe.Pointer)(src)
+			if pSrc == nil {
+				*p = nil
+				return
+			}
+			if *p == nil {
+				*p = reflect.New(dstElem).UnsafePointer()
+			}
+			f(*p, pSrc)
+		}
+	}
+}
+
+// zero is the zero value of any numeric type.
+var zero [16]byte
+
+func elem(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem(), true
+	}
+
+	return t, false
+}
+
+type numericClass int
+
+const (
+	notNumeric numericClass = iota
+	intClass
+	uintClass
+	floatClass
+)
+
+func classOf(k reflect.Kind) numericClass {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intClass
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintClass
+	case reflect.Float32, reflect.Float64:
+		return floatClass
+	default:
+		return notNumeric
+	}
+}
+
+// numericFunc returns the function converting integers to floats and vice versa.
+func numericFunc(dst, src reflect.Kind) func(dst, src unsafe.Pointer) {
+	switch dstClass, srcClass := classOf(dst), classOf(src); {
+	case dstClass == floatClass && srcClass == intClass:
+		r, w := intReader(src), floatWriter(dst)
+		return func(dst, src unsafe.Pointer) { w(dst, float64(r(src))) }
+	case dstClass == floatClass && srcClass == uintClass:
+		r, w := uintReader(src), floatWriter(dst)
+		return func(dst, src unsafe.Pointer) { w(dst, float64(r(src))) }
+	case dstClass == intClass && srcClass == floatClass:
+		r, w := floatReader(src), intWriter(dst)
+		return func(dst, src unsafe.Pointer) { w(dst, int64(r(src))) }
+	case dstClass == uintClass && srcClass == floatClass:
+		r, w := floatReader(src), uintWriter(dst)
+		return func(dst, src unsafe.Pointer) { w(dst, uint64(r(src))) }
+	default:
+		return nil
+	}
+}
+
+func intReader(k reflect.Kind) func(p unsafe.Pointer) int64 {
+	switch k {
+	case reflect.Int8:
+		return func(p unsafe.Pointer) int64 { return int64(*(*int8)(p)) }
+	case reflect.Int16:
+		return func(p unsafe.Pointer) int64 { return int64(*(*int16)(p)) }
+	case reflect.Int32:
+		return func(p unsafe.Pointer) int64 { return int64(*(*int32)(p)) }
+	case reflect.Int64:
+		return func(p unsafe.Pointer) int64 { return *(*int64)(p) }
+	default:
+		return func(p unsafe.Pointer) int64 { return int64(*(*int)(p)) }
+	}
+}
+
+func uintReader(k reflect.Kind) func(p unsafe.Pointer) uint64 {
+	switch k {
+	case reflect.Uint8:
+		return func(p unsafe.Pointer) uint64 { return uint64(*(*uint8)(p)) }
+	case reflect.Uint16:
+		return func(p unsafe.Pointer) uint64 { return uint64(*(*uint16)(p)) }
+	case reflect.Uint32:
+		return func(p unsafe.Pointer) uint64 { return uint64(*(*uint32)(p)) }
+	case reflect.Uint64:
+		return func(p unsafe.Pointer) uint64 { return *(*uint64)(p) }
+	case reflect.Uintptr:
+		return func(p unsafe.Pointer) uint64 { return uint64(*(*uintptr)(p)) }
+	default:
+		return func(p unsafe.Pointer) uint64 { return uint64(*(*uint)(p)) }
+	}
+}
+
+func floatReader(k reflect.Kind) func(p unsafe.Pointer) float64 {
+	if k == reflect.Float32 {
+		return func(p unsafe.Pointer) float64 { return float64(*(*float32)(p)) }
+	}
+
+	return func(p unsafe.Pointer) float64 { return *(*float64)(p) }
+}
+
+func intWriter(k reflect.Kind) func(p unsafe.Pointer, v int64) {
+	switch k {
+	case reflect.Int8:
+		return func(p unsafe.Pointer, v int64) { *(*int8)(p) = int8(v) }
+	case reflect.Int16:
+		return func(p unsafe.Pointer, v int64) { *(*int16)(p) = int16(v) }
+	case reflect.Int32:
+		return func(p unsafe.Pointer, v int64) { *(*int32)(p) = int32(v) }
+	case reflect.Int64:
+		return func(p unsafe.Pointer, v int64) { *(*int64)(p) = v }
+	default:
+		return func(p unsafe.Pointer, v int64) { *(*int)(p) = int(v) }
+	}
+}
+
+func uintWriter(k reflect.Kind) func(p unsafe.Pointer, v uint64) {
+	switch k {
+	case reflect.Uint8:
+		return func(p unsafe.Pointer, v uint64) { *(*uint8)(p) = uint8(v) }
+	case reflect.Uint16:
+		return func(p unsafe.Pointer, v uint64) { *(*uint16)(p) = uint16(v) }
+	case reflect.Uint32:
+		return func(p unsafe.Pointer, v uint64) { *(*uint32)(p) = uint32(v) }
+	case reflect.Uint64:
+		return func(p unsafe.Pointer, v uint64) { *(*uint64)(p) = v }
+	case reflect.Uintptr:
+		return func(p unsafe.Pointer, v uint64) { *(*uintptr)(p) = uintptr(v) }
+	default:
+		return func(p unsafe.Pointer, v uint64) { *(*uint)(p) = uint(v) }
+	}
+}
+
+func floatWriter(k reflect.Kind) func(p unsafe.Pointer, v float64) {
+	if k == reflect.Float32 {
+		return func(p unsafe.Pointer, v float64) { *(*float32)(p) = float32(v) }
+	}
+
+	return func(p unsafe.Pointer, v float64) { *(*float64)(p) = v }
+}
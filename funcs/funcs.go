@@ -25,32 +25,98 @@ func typeOfPointer(v interface{}) reflect.Type {
 
 // CopyFuncs is the storage of functions intended for copying data.
 type CopyFuncs struct {
-	mu    sync.RWMutex
-	funcs map[funcKey]func(dst, src unsafe.Pointer)
-	sizes []func(dst, src unsafe.Pointer)
+	mu     sync.RWMutex
+	funcs  map[funcKey]func(dst, src unsafe.Pointer)
+	names  map[funcKey]string
+	sizes  []func(dst, src unsafe.Pointer)
+	parent *CopyFuncs
+}
+
+// New returns the empty storage of functions that falls back to the default functions.
+func New() *CopyFuncs {
+	return funcs.Extend()
+}
+
+// Extend returns the empty storage of functions that falls back to t.
+// Functions set to the returned storage do not change t.
+func (t *CopyFuncs) Extend() *CopyFuncs {
+	return &CopyFuncs{
+		funcs:  map[funcKey]func(dst, src unsafe.Pointer){},
+		parent: t,
+	}
 }
 
 // Get the copy function for the pair of types, if it is not found then nil is returned.
+// Functions of the storage take precedence over functions of the parent storages. Named scalar types
+// fall back to the functions of the builtin types with the same underlying types.
 func (t *CopyFuncs) Get(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
-	t.mu.RLock()
-	f := t.funcs[funcKey{Src: src, Dst: dst}]
-	t.mu.RUnlock()
-	if f != nil {
-		return f
+	f, _ := t.GetNamed(dst, src)
+	return f
+}
+
+// GetNamed is like Get but also returns the name of the function given to SetNamed.
+// The name is empty for the functions set by Set and the generic functions.
+func (t *CopyFuncs) GetNamed(dst, src reflect.Type) (func(dst, src unsafe.Pointer), string) {
+	if f, name := t.lookup(dst, src); f != nil {
+		return f, name
 	}
 
-	if dst.Kind() != src.Kind() {
-		return nil
+	if bDst, bSrc := builtin(dst), builtin(src); bDst != dst || bSrc != src {
+		if f, name := t.lookup(bDst, bSrc); f != nil {
+			return f, name
+		}
 	}
 
-	if dst.Kind() == reflect.String {
-		// TODO
+	return t.root().get(dst, src), ""
+}
+
+// Lookup returns the function set for the pair of types to the storage or to its parents, without fallbacks
+// to the functions of the builtin types and generic functions. If it is not found then nil is returned.
+func (t *CopyFuncs) Lookup(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
+	f, _ := t.lookup(dst, src)
+	return f
+}
+
+// lookup returns the function set for the pair of types and its name.
+func (t *CopyFuncs) lookup(dst, src reflect.Type) (func(dst, src unsafe.Pointer), string) {
+	key := funcKey{Src: src, Dst: dst}
+	for s := t; s != nil; s = s.parent {
+		s.mu.RLock()
+		f, name := s.funcs[key], s.names[key]
+		s.mu.RUnlock()
+		if f != nil {
+			return f, name
+		}
+	}
+
+	return nil, ""
+}
+
+// root returns the storage without the parent.
+func (t *CopyFuncs) root() *CopyFuncs {
+	for t.parent != nil {
+		t = t.parent
+	}
+
+	return t
+}
+
+// get returns the generic function for the pair of types.
+func (t *CopyFuncs) get(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
+	if f := t.convertFunc(dst, src); f != nil {
+		return f
+	}
+
+	if dst.Kind() != src.Kind() {
 		return nil
 	}
 
 	same := dst == src
 
 	switch dst.Kind() {
+	case reflect.String:
+		// Strings have the same layout, whatever the types are.
+		same = true
 	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
 		same = same || dst.Elem() == src.Elem()
 	case reflect.Map:
@@ -66,8 +132,22 @@ func (t *CopyFuncs) Get(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
 
 // Set the copy function for the pair of types.
 func (t *CopyFuncs) Set(dst, src reflect.Type, f func(dst, src unsafe.Pointer)) {
+	t.SetNamed(dst, src, "", f)
+}
+
+// SetNamed sets the copy function for the pair of types with the name describing it, for example «Money -> string».
+func (t *CopyFuncs) SetNamed(dst, src reflect.Type, name string, f func(dst, src unsafe.Pointer)) {
+	key := funcKey{Src: src, Dst: dst}
 	t.mu.Lock()
-	t.funcs[funcKey{Src: src, Dst: dst}] = f
+	t.funcs[key] = f
+	if name != "" {
+		if t.names == nil {
+			t.names = map[funcKey]string{}
+		}
+		t.names[key] = name
+	} else {
+		delete(t.names, key)
+	}
 	t.mu.Unlock()
 }
 
@@ -76,11 +156,27 @@ func Get(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
 	return funcs.Get(dst, src)
 }
 
+// GetNamed is like Get but also returns the name of the function given to SetNamed.
+func GetNamed(dst, src reflect.Type) (func(dst, src unsafe.Pointer), string) {
+	return funcs.GetNamed(dst, src)
+}
+
+// Lookup returns the function set for the pair of types to the default functions, without fallbacks.
+func Lookup(dst, src reflect.Type) func(dst, src unsafe.Pointer) {
+	return funcs.Lookup(dst, src)
+}
+
 // Set the copy function for the pair of types.
+// It changes the default functions, that are used by all storages created by New.
 func Set(dst, src reflect.Type, f func(dst, src unsafe.Pointer)) {
 	funcs.Set(dst, src, f)
 }
 
+// SetNamed sets the copy function for the pair of types with the name describing it to the default functions.
+func SetNamed(dst, src reflect.Type, name string, f func(dst, src unsafe.Pointer)) {
+	funcs.SetNamed(dst, src, name, f)
+}
+
 var funcs = &CopyFuncs{
 	funcs: map[funcKey]func(dst, src unsafe.Pointer){},
 	sizes: []func(dst, src unsafe.Pointer){},
@@ -254,3 +254,134 @@ func TestGet(t *testing.T) {
 		t.Error("Get(map, map) should not return nil")
 	}
 }
+
+func TestNew(t *testing.T) {
+	intType := reflect.TypeOf(0)
+	stringType := reflect.TypeOf("")
+	timeType := reflect.TypeOf(time.Time{})
+
+	custom := func(dst, src unsafe.Pointer) {
+		*(*string)(dst) = (*time.Time)(src).Format(time.RFC3339)
+	}
+
+	f := New()
+	f.Set(stringType, timeType, custom)
+
+	if f.Get(stringType, timeType) == nil {
+		t.Error("the set function is not found")
+	}
+	if Get(stringType, timeType) != nil {
+		t.Error("the default functions must not be changed")
+	}
+	if f.Get(intType, intType) == nil {
+		t.Error("the default function is not found")
+	}
+
+	ext := f.Extend()
+	if ext.Get(stringType, timeType) == nil {
+		t.Error("the function of the parent is not found")
+	}
+
+	src := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	dst := ""
+	ext.Get(stringType, timeType)(unsafe.Pointer(&dst), unsafe.Pointer(&src))
+	if dst != "2021-01-02T03:04:05Z" {
+		t.Errorf("want %q, got %q", "2021-01-02T03:04:05Z", dst)
+	}
+
+	type Name string
+	ext.Set(stringType, stringType, func(dst, src unsafe.Pointer) {
+		*(*string)(dst) = "set"
+	})
+	name := Name("name")
+	ext.Get(stringType, typeOf(name))(unsafe.Pointer(&dst), unsafe.Pointer(&name))
+	if dst != "set" {
+		t.Errorf("named types must fall back to the set function of the builtin types, got %q", dst)
+	}
+	if ext.Lookup(stringType, typeOf(name)) != nil {
+		t.Error("Lookup must not fall back to the functions of the builtin types")
+	}
+	if ext.Lookup(stringType, timeType) == nil {
+		t.Error("Lookup must return the function of the parent")
+	}
+
+	ext.SetNamed(stringType, stringType, "string -> string", custom)
+	if _, n := ext.GetNamed(stringType, typeOf(name)); n != "string -> string" {
+		t.Errorf("want the name of the function, got %q", n)
+	}
+	ext.Set(stringType, stringType, custom)
+	if _, n := ext.GetNamed(stringType, stringType); n != "" {
+		t.Errorf("Set must reset the name of the function, got %q", n)
+	}
+}
+
+func TestConvertFunc(t *testing.T) {
+	type Status string
+	type UserID int64
+	type Celsius float64
+
+	status := Status("active")
+	var s string
+	Get(typeOf(s), typeOf(status))(unsafe.Pointer(&s), unsafe.Pointer(&status))
+	if s != "active" {
+		t.Errorf("want %q, got %q", "active", s)
+	}
+
+	// Strings of different types are copied as is without the functions of the builtin types.
+	bare := &CopyFuncs{funcs: map[funcKey]func(dst, src unsafe.Pointer){}, sizes: funcs.sizes}
+	s = ""
+	bare.Get(typeOf(s), typeOf(status))(unsafe.Pointer(&s), unsafe.Pointer(&status))
+	if s != "active" {
+		t.Errorf("want %q, got %q", "active", s)
+	}
+
+	var b []byte
+	Get(typeOf(b), typeOf(status))(unsafe.Pointer(&b), unsafe.Pointer(&status))
+	if string(b) != "active" {
+		t.Errorf("want %q, got %q", "active", b)
+	}
+
+	id := UserID(10)
+	var i int64
+	Get(typeOf(i), typeOf(id))(unsafe.Pointer(&i), unsafe.Pointer(&id))
+	if i != 10 {
+		t.Errorf("want 10, got %d", i)
+	}
+
+	celsius := Celsius(36.6)
+	var f float32
+	Get(typeOf(f), typeOf(celsius))(unsafe.Pointer(&f), unsafe.Pointer(&celsius))
+	if f != 36.6 {
+		t.Errorf("want 36.6, got %f", f)
+	}
+
+	var pi *int8
+	Get(typeOfPointer(int8(0)), typeOf(celsius))(unsafe.Pointer(&pi), unsafe.Pointer(&celsius))
+	if pi == nil || *pi != 36 {
+		t.Errorf("want 36, got %v", pi)
+	}
+
+	var pc *Celsius
+	Get(typeOf(f), typeOfPointer(celsius))(unsafe.Pointer(&f), unsafe.Pointer(&pc))
+	if f != 0 {
+		t.Errorf("want 0, got %f", f)
+	}
+
+	pu := ptr.UInt(7)
+	Get(typeOfPointer(celsius), typeOfPointer(uint(0)))(unsafe.Pointer(&pc), unsafe.Pointer(&pu))
+	if pc == nil || *pc != 7 {
+		t.Errorf("want 7, got %v", pc)
+	}
+	pu = nil
+	Get(typeOfPointer(celsius), typeOfPointer(uint(0)))(unsafe.Pointer(&pc), unsafe.Pointer(&pu))
+	if pc != nil {
+		t.Errorf("want nil, got %v", *pc)
+	}
+
+	if Get(typeOf(""), typeOf(id)) != nil {
+		t.Error("integers must not be converted to strings")
+	}
+	if Get(typeOf(false), typeOf(id)) != nil {
+		t.Error("integers must not be converted to bools")
+	}
+}
@@ -0,0 +1,103 @@
+package copy
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// TypedCopier copies values of the Src type to values of the Dst type.
+// Types are checked at compile time, so copying has no runtime checks.
+type TypedCopier[Dst, Src any] struct {
+	copier internalCopier
+}
+
+// For returns the typed copier for a specific destination and source types.
+//
+//	copier := copy.For[Employee, User](copiers)
+//	copier.Copy(&dst, &src)
+func For[Dst, Src any](c *Copiers) TypedCopier[Dst, Src] {
+	copier, err := ForE[Dst, Src](c)
+	if err != nil {
+		panic(err)
+	}
+
+	return copier
+}
+
+// ForE is like For but returns an error instead of panic.
+func ForE[Dst, Src any](c *Copiers) (TypedCopier[Dst, Src], error) {
+	copier, err := c.GetE((*Dst)(nil), (*Src)(nil))
+	if err != nil {
+		return TypedCopier[Dst, Src]{}, err
+	}
+
+	return TypedCopier[Dst, Src]{copier: copier.(internalCopier)}, nil
+}
+
+// Copy copies the contents of src into dst. It panics with ErrZeroCopier if the copier is the zero value.
+func (c TypedCopier[Dst, Src]) Copy(dst *Dst, src *Src) {
+	if c.copier == nil {
+		panic(ErrZeroCopier)
+	}
+	c.copier.copy(unsafe.Pointer(dst), unsafe.Pointer(src))
+}
+
+// CopyE is like Copy but returns an error instead of panic.
+func (c TypedCopier[Dst, Src]) CopyE(dst *Dst, src *Src) (err error) {
+	if c.copier == nil {
+		return ErrZeroCopier
+	}
+	if src == nil {
+		return fmt.Errorf("source %w", ErrNilPointer)
+	}
+	if dst == nil {
+		return fmt.Errorf("destination %w", ErrNilPointer)
+	}
+
+	defer catch(&err)
+	c.copier.copy(unsafe.Pointer(dst), unsafe.Pointer(src))
+
+	return nil
+}
+
+// Convert returns the value of the Dst type filled from src. Src can be a value or a pointer to a value.
+//
+//	employee := copy.Convert[Employee](user)
+func Convert[Dst any](src any) Dst {
+	dst, err := ConvertE[Dst](src)
+	if err != nil {
+		panic(err)
+	}
+
+	return dst
+}
+
+// ConvertE is like Convert but returns an error instead of panic.
+func ConvertE[Dst any](src any) (Dst, error) {
+	var dst Dst
+
+	if v := reflect.ValueOf(src); v.IsValid() && v.Kind() != reflect.Ptr {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		src = ptr.Interface()
+	}
+
+	err := defaultCopier.CopyE(&dst, src)
+
+	return dst, err
+}
+
+// ConvertSlice returns the slice of the Dst type values filled from the src slice.
+//
+//	employees := copy.ConvertSlice[Employee](users)
+func ConvertSlice[Dst, Src any](src []Src) []Dst {
+	if src == nil {
+		return nil
+	}
+
+	var dst []Dst
+	For[[]Dst, []Src](defaultCopier).Copy(&dst, &src)
+
+	return dst
+}
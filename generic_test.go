@@ -0,0 +1,97 @@
+package copy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFor(t *testing.T) {
+	type testStruct1 struct {
+		S string
+		I int
+	}
+	type testStruct2 struct {
+		S string
+		I int64
+	}
+
+	src := testStruct1{S: "string", I: 10}
+	dst := testStruct2{}
+
+	For[testStruct2, testStruct1](New()).Copy(&dst, &src)
+	equal(t, dst, src)
+
+	if err := For[testStruct2, testStruct1](New()).CopyE(nil, &src); !errors.Is(err, ErrNilPointer) {
+		t.Errorf("want ErrNilPointer got %v", err)
+	}
+	if err := For[testStruct2, testStruct1](New()).CopyE(&dst, nil); !errors.Is(err, ErrNilPointer) {
+		t.Errorf("want ErrNilPointer got %v", err)
+	}
+
+	if _, err := ForE[int, testStruct1](New()); !errors.As(err, new(*UnsupportedTypesError)) {
+		t.Errorf("want UnsupportedTypesError got %v", err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("must panic when types are not supported")
+			}
+		}()
+		For[int, testStruct1](New())
+	}()
+
+	var zero TypedCopier[testStruct2, testStruct1]
+	if err := zero.CopyE(&dst, &src); !errors.Is(err, ErrZeroCopier) {
+		t.Errorf("want ErrZeroCopier got %v", err)
+	}
+	func() {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, ErrZeroCopier) {
+				t.Errorf("want the panic with ErrZeroCopier got %v", err)
+			}
+		}()
+		zero.Copy(&dst, &src)
+	}()
+}
+
+func TestConvert(t *testing.T) {
+	type testStruct1 struct {
+		S string
+		I int
+	}
+	type testStruct2 struct {
+		S string
+		I int64
+	}
+
+	src := testStruct1{S: "string", I: 10}
+
+	equal(t, Convert[testStruct2](src), src)
+	equal(t, Convert[testStruct2](&src), src)
+	equal(t, *Convert[*testStruct2](src), src)
+
+	if _, err := ConvertE[testStruct2](nil); !errors.Is(err, ErrNotPointer) {
+		t.Errorf("want ErrNotPointer got %v", err)
+	}
+}
+
+func TestConvertSlice(t *testing.T) {
+	type testStruct1 struct {
+		S string
+		I int
+	}
+	type testStruct2 struct {
+		S string
+		I int64
+	}
+
+	src := []testStruct1{{S: "1", I: 1}, {S: "2", I: 2}}
+
+	dst := ConvertSlice[testStruct2](src)
+	equal(t, dst, src)
+
+	if ConvertSlice[testStruct2]([]testStruct1(nil)) != nil {
+		t.Error("must return nil for nil slice")
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/gotidy/copy
 
-go 1.15
+go 1.18
 
 require github.com/gotidy/ptr v1.3.0
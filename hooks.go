@@ -0,0 +1,81 @@
+package copy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// BeforeCopier is implemented by destination types that are prepared before copying of a source into them.
+// Src is the pointer to the source value.
+type BeforeCopier interface {
+	BeforeCopy(src interface{}) error
+}
+
+// AfterCopier is implemented by destination types that compute derived fields or normalize data
+// after copying of a source into them. Src is the pointer to the source value.
+type AfterCopier interface {
+	AfterCopy(src interface{}) error
+}
+
+var (
+	beforeCopierType = reflect.TypeOf((*BeforeCopier)(nil)).Elem()
+	afterCopierType  = reflect.TypeOf((*AfterCopier)(nil)).Elem()
+)
+
+// hooks reports whether the pointer to the type implements BeforeCopier and AfterCopier.
+func hooks(t reflect.Type) (before, after bool) {
+	ptr := reflect.PtrTo(t)
+	return ptr.Implements(beforeCopierType), ptr.Implements(afterCopierType)
+}
+
+// hasHooks reports whether the pointer to the type implements BeforeCopier or AfterCopier.
+func hasHooks(t reflect.Type) bool {
+	before, after := hooks(t)
+	return before || after
+}
+
+// reachesHooks reports whether the type or any type reachable from it by fields, pointers, slices, arrays and maps has hooks.
+func reachesHooks(t reflect.Type) bool {
+	return reaches(t, make(map[reflect.Type]bool))
+}
+
+func reaches(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if hasHooks(t) {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if reaches(t.Field(i).Type, visited) {
+				return true
+			}
+		}
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return reaches(t.Elem(), visited)
+	case reflect.Map:
+		return reaches(t.Key(), visited) || reaches(t.Elem(), visited)
+	}
+
+	return false
+}
+
+// beforeCopy calls BeforeCopy of the destination, the error is thrown.
+func (c *StructCopier) beforeCopy(dst, src unsafe.Pointer) {
+	d := reflect.NewAt(c.dstType, dst).Interface().(BeforeCopier)
+	if err := d.BeforeCopy(reflect.NewAt(c.srcType, src).Interface()); err != nil {
+		throw(err)
+	}
+}
+
+// afterCopy calls AfterCopy of the destination, the error is thrown.
+func (c *StructCopier) afterCopy(dst, src unsafe.Pointer) {
+	d := reflect.NewAt(c.dstType, dst).Interface().(AfterCopier)
+	if err := d.AfterCopy(reflect.NewAt(c.srcType, src).Interface()); err != nil {
+		throw(err)
+	}
+}
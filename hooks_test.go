@@ -0,0 +1,135 @@
+package copy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type hookPerson struct {
+	FirstName string
+	LastName  string
+}
+
+type hookEmployee struct {
+	FirstName string
+	LastName  string
+	FullName  string
+	Source    string
+}
+
+func (e *hookEmployee) BeforeCopy(src interface{}) error {
+	if p, ok := src.(*hookPerson); ok && p.FirstName == "" {
+		return errors.New("first name is empty")
+	}
+	return nil
+}
+
+func (e *hookEmployee) AfterCopy(src interface{}) error {
+	e.FullName = strings.TrimSpace(e.FirstName + " " + e.LastName)
+	switch src.(type) {
+	case *hookPerson:
+		e.Source = "person"
+	case *hookEmployee:
+		e.Source = "employee"
+	}
+	return nil
+}
+
+type hookSecret struct {
+	secret int
+	Y      int
+	Copied bool
+}
+
+func (h *hookSecret) AfterCopy(src interface{}) error {
+	h.Copied = true
+	return nil
+}
+
+func TestCopier_Hooks(t *testing.T) {
+	type testStruct1 struct {
+		Boss  hookPerson
+		Staff []hookPerson
+		Copy  hookEmployee
+	}
+	type testStruct2 struct {
+		Boss  hookEmployee
+		Staff []hookEmployee
+		Copy  hookEmployee
+	}
+
+	c := New()
+
+	dst := hookEmployee{}
+	c.Copy(&dst, &hookPerson{FirstName: "John", LastName: "Smith"})
+	equal(t, dst, hookEmployee{FirstName: "John", LastName: "Smith", FullName: "John Smith", Source: "person"})
+
+	dst2 := testStruct2{}
+	c.Copy(&dst2, &testStruct1{
+		Boss:  hookPerson{FirstName: "John", LastName: "Smith"},
+		Staff: []hookPerson{{FirstName: "Bob"}},
+		Copy:  hookEmployee{FirstName: "Alice"},
+	})
+	equal(t, dst2, testStruct2{
+		Boss:  hookEmployee{FirstName: "John", LastName: "Smith", FullName: "John Smith", Source: "person"},
+		Staff: []hookEmployee{{FirstName: "Bob", FullName: "Bob", Source: "person"}},
+		Copy:  hookEmployee{FirstName: "Alice", FullName: "Alice", Source: "employee"},
+	})
+
+	err := c.CopyE(&dst2, &testStruct1{Boss: hookPerson{FirstName: "John"}, Staff: []hookPerson{{LastName: "Smith"}}})
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Staff[]" {
+		t.Errorf("want the error of «Staff[]», got %v", err)
+	}
+
+	if err := c.CopyE(&dst, &hookPerson{}); err == nil || err.Error() != "first name is empty" {
+		t.Errorf("want the hook error, got %v", err)
+	}
+}
+
+func TestCopier_HooksUnexported(t *testing.T) {
+	type testStruct struct {
+		H hookSecret
+	}
+
+	dst := testStruct{}
+	New().Copy(&dst, &testStruct{H: hookSecret{secret: 5, Y: 1}})
+	if dst != (testStruct{H: hookSecret{secret: 5, Y: 1, Copied: true}}) {
+		t.Errorf("want unexported fields copied and the hook called, got %+v", dst)
+	}
+}
+
+type hookItemDTO struct {
+	Count int
+	Twice int
+}
+
+func (i *hookItemDTO) AfterCopy(src interface{}) error {
+	i.Twice = i.Count * 2
+	return nil
+}
+
+func TestCopier_HooksRegistered(t *testing.T) {
+	type item struct {
+		Count int
+	}
+	type order struct {
+		Items []item
+	}
+	type orderDTO struct {
+		Items []hookItemDTO
+	}
+
+	// The registered function does not call hooks of nested structs, so it is not used.
+	Register(func(dst *orderDTO, src *order) {
+		dst.Items = make([]hookItemDTO, len(src.Items))
+		for i := range src.Items {
+			dst.Items[i].Count = src.Items[i].Count
+		}
+	})
+
+	dst := orderDTO{}
+	Copy(&dst, &order{Items: []item{{Count: 2}}})
+	equal(t, dst, orderDTO{Items: []hookItemDTO{{Count: 2, Twice: 4}}})
+}
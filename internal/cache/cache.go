@@ -14,12 +14,34 @@ type Field struct {
 	Anonymous  bool
 	Offset     uintptr
 	ParentName string
+	// OmitEmpty is set by the «omitempty» tag option, the field is not copied if the source value is empty.
+	OmitEmpty bool
+	// Required is set by the «required» tag option, the destination field must have the source field.
+	Required bool
+	// ReadOnly is set by the «readonly» tag option, the field is not used as a destination.
+	ReadOnly bool
+	// WriteOnly is set by the «writeonly» tag option, the field is not used as a source.
+	WriteOnly bool
+	// Default is set by the «default=value» tag option, the value fills the destination field
+	// if the source field is empty or missing.
+	Default    string
+	HasDefault bool
+	// Layout is set by the «layout=value» tag option, it is the layout of times converted to and from the field.
+	Layout string
+	// TimeZone is set by the «tz=name» tag option, it is the location of times converted to and from the field.
+	TimeZone string
+	// UnknownOptions are the tag options, that are not recognized, for example misspelled.
+	UnknownOptions []string
 }
 
 // Struct fields info.
 type Struct struct {
 	Fields []Field
 	Names  map[string]Field
+	// Keys are fields by normalized names, it is set if the normalizer is set.
+	// Fields with equal names are stored once.
+	Keys      map[string][]Field
+	normalize func(name string) string
 }
 
 type tagKind int
@@ -30,24 +52,27 @@ const (
 	tagEmbed
 )
 
-func parseTag(tag string) (name string, kind tagKind) {
+func parseTag(tag string) (name string, kind tagKind, options []string) {
 	switch tag {
 	case "-":
-		return "", tagOmit
+		return "", tagOmit, nil
 	case "+":
-		return "", tagEmbed
+		return "", tagEmbed, nil
 	}
 
 	if idx := strings.Index(tag, ","); idx != -1 {
-		return tag[:idx], tagNormal
+		return tag[:idx], tagNormal, strings.Split(tag[idx+1:], ",")
 	}
 
-	return tag, tagNormal
+	return tag, tagNormal, nil
 }
 
-// NewStruct inits the new struct info.
-func NewStruct(t reflect.Type, tagName string) Struct {
-	s := Struct{Fields: make([]Field, 0, t.NumField()), Names: make(map[string]Field, t.NumField())}
+// NewStruct inits the new struct info. If normalize is not nil, then fields are also indexed by normalized names.
+func NewStruct(t reflect.Type, tagName string, normalize func(name string) string) Struct {
+	s := Struct{Fields: make([]Field, 0, t.NumField()), Names: make(map[string]Field, t.NumField()), normalize: normalize}
+	if normalize != nil {
+		s.Keys = make(map[string][]Field, t.NumField())
+	}
 
 	var traverse func(t reflect.Type, name string, offset uintptr)
 	traverse = func(t reflect.Type, name string, offset uintptr) {
@@ -67,7 +92,7 @@ func NewStruct(t reflect.Type, tagName string) Struct {
 
 			if tagName != "" {
 				if tag, ok := field.Tag.Lookup(tagName); ok {
-					s, kind := parseTag(tag)
+					s, kind, options := parseTag(tag)
 					switch kind {
 					case tagOmit:
 						continue
@@ -75,6 +100,28 @@ func NewStruct(t reflect.Type, tagName string) Struct {
 						fi.Anonymous = field.Type.Kind() == reflect.Struct
 					}
 
+					for _, option := range options {
+						switch {
+						case option == "omitempty":
+							fi.OmitEmpty = true
+						case option == "required":
+							fi.Required = true
+						case option == "readonly":
+							fi.ReadOnly = true
+						case option == "writeonly":
+							fi.WriteOnly = true
+						case strings.HasPrefix(option, "default="):
+							fi.Default = strings.TrimPrefix(option, "default=")
+							fi.HasDefault = true
+						case strings.HasPrefix(option, "layout="):
+							fi.Layout = strings.TrimPrefix(option, "layout=")
+						case strings.HasPrefix(option, "tz="):
+							fi.TimeZone = strings.TrimPrefix(option, "tz=")
+						case option != "":
+							fi.UnknownOptions = append(fi.UnknownOptions, option)
+						}
+					}
+
 					if s != "" {
 						fi.Name = s
 					}
@@ -83,6 +130,9 @@ func NewStruct(t reflect.Type, tagName string) Struct {
 
 			s.Fields = append(s.Fields, fi)
 			s.Names[fi.Name] = fi
+			if normalize != nil {
+				s.addKey(normalize(fi.Name), fi)
+			}
 
 			if fi.Anonymous {
 				traverse(fi.Type, fi.Name, fi.Offset)
@@ -107,6 +157,32 @@ func (s Struct) FieldByName(name string) (Field, bool) {
 	return f, ok
 }
 
+// FieldsByName returns the struct fields matched with the given name.
+// If the normalizer is set, then fields with the same normalized name are returned,
+// so more than one field means that the match is ambiguous.
+func (s Struct) FieldsByName(name string) []Field {
+	if s.normalize == nil {
+		if f, ok := s.Names[name]; ok {
+			return []Field{f}
+		}
+		return nil
+	}
+
+	return s.Keys[s.normalize(name)]
+}
+
+// addKey adds the field to the fields with the key, the field with the same name is replaced.
+func (s Struct) addKey(key string, field Field) {
+	fields := s.Keys[key]
+	for i, f := range fields {
+		if f.Name == field.Name {
+			fields[i] = field
+			return
+		}
+	}
+	s.Keys[key] = append(fields, field)
+}
+
 // NumField returns a struct type's field count.
 // It panics if the type's Kind is not Struct.
 func (s Struct) NumField() int {
@@ -115,14 +191,15 @@ func (s Struct) NumField() int {
 
 // Cache is structs' cache.
 type Cache struct {
-	mu      sync.RWMutex
-	tag     string
-	structs map[reflect.Type]Struct
+	mu        sync.RWMutex
+	tag       string
+	normalize func(name string) string
+	structs   map[reflect.Type]Struct
 }
 
-// New creates structs Cache.
-func New(tagName string) *Cache {
-	return &Cache{tag: tagName, structs: make(map[reflect.Type]Struct)}
+// New creates structs Cache. If normalize is not nil, then fields are also indexed by normalized names.
+func New(tagName string, normalize func(name string) string) *Cache {
+	return &Cache{tag: tagName, normalize: normalize, structs: make(map[reflect.Type]Struct)}
 }
 
 // Get returns struct fields info.
@@ -148,7 +225,7 @@ func (c *Cache) GetByType(t reflect.Type) Struct {
 		panic(fmt.Errorf("type %s is not struct", t))
 	}
 
-	s = NewStruct(t, c.tag)
+	s = NewStruct(t, c.tag, c.normalize)
 	c.mu.Lock()
 	c.structs[t] = s
 	c.mu.Unlock()
@@ -0,0 +1,95 @@
+package copy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// MapCopier fills a destination map from a source map converting keys and elements.
+type MapCopier struct {
+	BaseCopier
+
+	keyCopier  func(dst, src unsafe.Pointer)
+	elemCopier func(dst, src unsafe.Pointer)
+}
+
+func NewMapCopier(c *Copiers) *MapCopier {
+	copier := &MapCopier{BaseCopier: NewBaseCopier(c)}
+	return copier
+}
+
+func (c *MapCopier) init(dst, src reflect.Type) (err error) {
+	c.BaseCopier.init(dst, src)
+
+	var keyThrows, elemThrows bool
+	c.keyCopier, keyThrows, err = c.getCopierFunc(dst.Key(), src.Key(), 0, 0)
+	if err != nil {
+		return fieldError("[key]", dst.Key(), src.Key(), err)
+	}
+	if c.keyCopier == nil && !c.options.Skip {
+		return fieldError("[key]", dst.Key(), src.Key(), &UnsupportedTypesError{Dst: dst.Key(), Src: src.Key()})
+	}
+	c.elemCopier, elemThrows, err = c.getCopierFunc(dst.Elem(), src.Elem(), 0, 0)
+	if err != nil {
+		return fieldError("[]", dst.Elem(), src.Elem(), err)
+	}
+	if c.elemCopier == nil && !c.options.Skip {
+		return fieldError("[]", dst.Elem(), src.Elem(), &UnsupportedTypesError{Dst: dst.Elem(), Src: src.Elem()})
+	}
+	if keyThrows {
+		c.keyCopier = withPath(c.keyCopier, "[key]", dst.Key(), src.Key())
+	}
+	if elemThrows {
+		c.elemCopier = withPath(c.elemCopier, "[]", dst.Elem(), src.Elem())
+	}
+	c.throwing = keyThrows || elemThrows
+
+	return nil
+}
+
+// Copy copies the contents of src into dst. Dst and src each must be a pointer to map.
+func (c *MapCopier) Copy(dst, src interface{}) {
+	mustCopy(c, dst, src)
+}
+
+// CopyE is like Copy but returns an error instead of panic.
+func (c *MapCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
+}
+
+func (c *MapCopier) copy(dst, src unsafe.Pointer) {
+	if c.keyCopier == nil || c.elemCopier == nil {
+		return
+	}
+
+	srcMap := reflect.NewAt(c.srcType, src).Elem()
+	dstMap := reflect.NewAt(c.dstType, dst).Elem()
+	if srcMap.IsNil() {
+		dstMap.Set(reflect.Zero(c.dstType))
+		return
+	}
+
+	m := reflect.MakeMapWithSize(c.dstType, srcMap.Len())
+
+	srcKey := reflect.New(c.srcType.Key()).Elem()
+	srcElem := reflect.New(c.srcType.Elem()).Elem()
+	dstKey := reflect.New(c.dstType.Key()).Elem()
+	dstElem := reflect.New(c.dstType.Elem()).Elem()
+	dstKeyZero := reflect.Zero(c.dstType.Key())
+	dstElemZero := reflect.Zero(c.dstType.Elem())
+
+	iter := srcMap.MapRange()
+	for iter.Next() {
+		srcKey.Set(iter.Key())
+		srcElem.Set(iter.Value())
+		// Reset destination values, else pointers would be shared between elements.
+		dstKey.Set(dstKeyZero)
+		dstElem.Set(dstElemZero)
+
+		c.keyCopier(unsafe.Pointer(dstKey.Addr().Pointer()), unsafe.Pointer(srcKey.Addr().Pointer()))
+		c.elemCopier(unsafe.Pointer(dstElem.Addr().Pointer()), unsafe.Pointer(srcElem.Addr().Pointer()))
+		m.SetMapIndex(dstKey, dstElem)
+	}
+
+	dstMap.Set(m)
+}
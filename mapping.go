@@ -0,0 +1,154 @@
+package copy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrFieldNotFound is returned when a mapped field is not found in a struct.
+var ErrFieldNotFound = errors.New("field not found")
+
+// fieldMapping is the explicit mapping of fields for a pair of struct types.
+type fieldMapping struct {
+	fields     []mappedField // In the order of the mapping.
+	ignore     map[string]bool
+	exhaustive bool
+}
+
+type mappedField struct {
+	dst string
+	src string
+}
+
+// skip reports whether the destination field is not matched by the name.
+func (m *fieldMapping) skip(dst string) bool {
+	if m == nil {
+		return false
+	}
+	if m.ignore[dst] {
+		return true
+	}
+	for _, f := range m.fields {
+		if f.dst == dst {
+			return true
+		}
+	}
+
+	return false
+}
+
+// strict reports whether all destination fields must have source fields.
+func (m *fieldMapping) strict() bool {
+	return m != nil && m.exhaustive
+}
+
+// Mapping changes the matching of fields for a pair of struct types.
+type Mapping func(m *fieldMapping)
+
+// Field fills the dst field from the src field instead of the field with the same name.
+// Names are the field names or the names set by the tag, nested fields are set by dotted paths, for example «Address.City».
+func Field(dst, src string) Mapping {
+	return func(m *fieldMapping) {
+		m.fields = append(m.fields, mappedField{dst: dst, src: src})
+	}
+}
+
+// Ignore leaves the dst field untouched.
+func Ignore(dst string) Mapping {
+	return func(m *fieldMapping) {
+		m.ignore[dst] = true
+	}
+}
+
+// Exhaustive requires every destination field of the pair to have a source field, like the Strict option does.
+// Ignored fields are not required.
+func Exhaustive() Mapping {
+	return func(m *fieldMapping) {
+		m.exhaustive = true
+	}
+}
+
+// Map sets the mapping of fields for the pair of struct types, it overrides the matching by names.
+// Dst and src each must be a pointer to struct. Map must be called before copying of the types,
+// because nested copiers that are already created are not changed.
+//
+//	c.Map(&Employee{}, &User{}, copy.Field("FullName", "Name"), copy.Ignore("Password"))
+func (c *Copiers) Map(dst, src interface{}, mappings ...Mapping) {
+	if err := c.MapE(dst, src, mappings...); err != nil {
+		panic(err)
+	}
+}
+
+// MapE is like Map but returns an error instead of panic.
+func (c *Copiers) MapE(dst, src interface{}, mappings ...Mapping) error {
+	srcType := reflect.TypeOf(src)
+	if srcType == nil || srcType.Kind() != reflect.Ptr {
+		return fmt.Errorf("source %w", ErrNotPointer)
+	}
+	srcType = srcType.Elem()
+
+	dstType := reflect.TypeOf(dst)
+	if dstType == nil || dstType.Kind() != reflect.Ptr {
+		return fmt.Errorf("destination %w", ErrNotPointer)
+	}
+	dstType = dstType.Elem()
+
+	if dstType.Kind() != reflect.Struct || srcType.Kind() != reflect.Struct {
+		return &UnsupportedTypesError{Dst: dstType, Src: srcType}
+	}
+
+	m := &fieldMapping{ignore: make(map[string]bool)}
+	for _, mapping := range mappings {
+		mapping(m)
+	}
+
+	for _, f := range m.fields {
+		if _, ok := c.resolvePath(dstType, f.dst); !ok {
+			return fieldError(f.dst, dstType, srcType, ErrFieldNotFound)
+		}
+		if _, ok := c.resolvePath(srcType, f.src); !ok {
+			return fieldError(f.src, dstType, srcType, ErrFieldNotFound)
+		}
+	}
+	for name := range m.ignore {
+		if _, ok := c.resolvePath(dstType, name); !ok {
+			return fieldError(name, dstType, srcType, ErrFieldNotFound)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := copierKey{Src: srcType, Dest: dstType}
+	c.mappings[key] = m
+
+	// The copier for the pair is created again.
+	if copier, ok := c.copiers[key]; ok {
+		delete(c.copiers, key)
+		for k, v := range c.indirectCopiers {
+			if v == copier {
+				delete(c.indirectCopiers, k)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mapping returns the mapping of fields for the pair of types or nil.
+func (c *Copiers) mapping(dst, src reflect.Type) *fieldMapping {
+	return c.mappings[copierKey{Src: src, Dest: dst}]
+}
+
+// Map sets the mapping of fields for the pair of struct types of the default Copiers.
+//
+//	copy.Map(&Employee{}, &User{}, copy.Field("FullName", "Name"), copy.Ignore("Password"))
+func Map(dst, src interface{}, mappings ...Mapping) {
+	defaultCopier.Map(dst, src, mappings...)
+}
+
+// MapE is like Map but returns an error instead of panic.
+func MapE(dst, src interface{}, mappings ...Mapping) error {
+	return defaultCopier.MapE(dst, src, mappings...)
+}
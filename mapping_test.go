@@ -0,0 +1,78 @@
+package copy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCopiers_Map(t *testing.T) {
+	type testStruct1 struct {
+		Name     string
+		Password string
+		Age      int
+	}
+	type testStruct2 struct {
+		FullName string
+		Name     string
+		Password string
+		Years    int64
+		Age      int
+	}
+
+	c := New()
+	src := testStruct1{Name: "John", Password: "secret", Age: 33}
+
+	dst := testStruct2{}
+	c.Copy(&dst, &src)
+	equal(t, dst, testStruct2{Name: "John", Password: "secret", Age: 33})
+
+	c.Map(&testStruct2{}, &testStruct1{},
+		Field("FullName", "Name"),
+		Field("Years", "Age"),
+		Field("Age", "Name"),
+		Ignore("Age"),
+		Ignore("Password"),
+	)
+
+	dst = testStruct2{Password: "untouched"}
+	c.Copy(&dst, &src)
+	equal(t, dst, testStruct2{FullName: "John", Name: "John", Password: "untouched", Years: 33})
+
+	// Other copiers are not affected.
+	dst = testStruct2{}
+	New().Copy(&dst, &src)
+	equal(t, dst, testStruct2{Name: "John", Password: "secret", Age: 33})
+}
+
+func TestCopiers_MapE(t *testing.T) {
+	type testStruct1 struct {
+		Name string
+	}
+	type testStruct2 struct {
+		FullName string
+	}
+
+	c := New()
+	if err := c.MapE(&testStruct2{}, &testStruct1{}, Field("Unknown", "Name")); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("want ErrFieldNotFound, got %v", err)
+	}
+	if err := c.MapE(&testStruct2{}, &testStruct1{}, Field("FullName", "Unknown")); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("want ErrFieldNotFound, got %v", err)
+	}
+	if err := c.MapE(&testStruct2{}, &testStruct1{}, Ignore("Unknown")); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("want ErrFieldNotFound, got %v", err)
+	}
+	if err := c.MapE(testStruct2{}, &testStruct1{}); !errors.Is(err, ErrNotPointer) {
+		t.Errorf("want ErrNotPointer, got %v", err)
+	}
+	if err := c.MapE(&testStruct2{}, new(int)); !errors.As(err, new(*UnsupportedTypesError)) {
+		t.Errorf("want UnsupportedTypesError, got %v", err)
+	}
+	if err := c.MapE(&testStruct2{}, &testStruct1{}, Field("FullName", "Name")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dst := testStruct2{}
+	c.Copy(&dst, &testStruct1{Name: "John"})
+	equal(t, dst, testStruct2{FullName: "John"})
+}
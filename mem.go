@@ -21,11 +21,59 @@ func memcopy(dst, src unsafe.Pointer, size int) {
 	copy(dstSlice, srcSlice)
 }
 
-func alloc(size int) unsafe.Pointer {
-	if size == 0 {
+// hasPointers reports whether values of the type contain pointers that must be visible to the garbage collector.
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// allocator allocates memory for values of the specific type.
+// Memory for values without pointers is allocated as int64 slices that is faster,
+// else memory is allocated via reflect, so the garbage collector knows about pointers inside.
+type allocator struct {
+	typ       reflect.Type
+	sliceType reflect.Type
+	size      uintptr
+	pointers  bool
+}
+
+func newAllocator(t reflect.Type) allocator {
+	return allocator{typ: t, sliceType: reflect.SliceOf(t), size: t.Size(), pointers: hasPointers(t)}
+}
+
+// New allocates a zero value and returns the pointer to it.
+func (a allocator) New() unsafe.Pointer {
+	if a.pointers || a.size == 0 {
+		return unsafe.Pointer(reflect.New(a.typ).Pointer())
+	}
+	size := (a.size + 7) / 8 // size in int64
+	return unsafe.Pointer(&(make([]int64, size)[0]))
+}
+
+// Array allocates the array of len zero values and returns the pointer to the first element.
+func (a allocator) Array(len int) unsafe.Pointer {
+	if len == 0 || a.size == 0 {
 		return nil
 	}
-	size = (size + 7) / 8 // size in int64
+	if a.pointers {
+		return unsafe.Pointer(reflect.MakeSlice(a.sliceType, len, len).Pointer())
+	}
+	size := (a.size*uintptr(len) + 7) / 8 // size in int64
 	return unsafe.Pointer(&(make([]int64, size)[0]))
 }
 
@@ -41,14 +89,14 @@ func sliceAt(ptr unsafe.Pointer, size uintptr) slice {
 	return slice{data: unsafe.Pointer(s.Data), size: size, Len: s.Len}
 }
 
-func makeSliceAt(ptr unsafe.Pointer, size uintptr, len int) slice {
+func makeSliceAt(ptr unsafe.Pointer, elem allocator, len int) slice {
 	s := (*reflect.SliceHeader)(ptr)
 	if s.Cap < len || s.Cap > len*2 {
-		s.Data = uintptr(alloc(int(size) * len))
+		s.Data = uintptr(elem.Array(len))
 		s.Cap = len
 	}
 	s.Len = len
-	return slice{data: unsafe.Pointer(s.Data), size: size, Len: s.Len}
+	return slice{data: unsafe.Pointer(s.Data), size: elem.size, Len: s.Len}
 }
 
 func (s slice) Index(i int) unsafe.Pointer {
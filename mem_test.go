@@ -1,6 +1,8 @@
 package copy
 
 import (
+	"reflect"
+	"runtime"
 	"testing"
 	"unsafe"
 )
@@ -18,7 +20,7 @@ func Test_sliceAt(t *testing.T) {
 func Test_makeSliceAt(t *testing.T) {
 	const size = 10
 	var ii []int
-	s := makeSliceAt(unsafe.Pointer(&ii), unsafe.Sizeof(ii[0]), size)
+	s := makeSliceAt(unsafe.Pointer(&ii), newAllocator(reflect.TypeOf(ii).Elem()), size)
 	if len(ii) != size {
 		t.Errorf("actual slice size is %v, expected %v", len(ii), size)
 	}
@@ -29,3 +31,57 @@ func Test_makeSliceAt(t *testing.T) {
 		}
 	}
 }
+
+func Test_hasPointers(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected bool
+	}{
+		{value: int(0), expected: false},
+		{value: [4]float64{}, expected: false},
+		{value: struct{ A, B int }{}, expected: false},
+		{value: "", expected: true},
+		{value: []int{}, expected: true},
+		{value: [2]*int{}, expected: true},
+		{value: struct {
+			A int
+			S string
+		}{}, expected: true},
+		{value: map[int]int{}, expected: true},
+	}
+	for _, test := range tests {
+		if actual := hasPointers(reflect.TypeOf(test.value)); actual != test.expected {
+			t.Errorf("hasPointers(%T) = %v, want %v", test.value, actual, test.expected)
+		}
+	}
+}
+
+func Test_allocator(t *testing.T) {
+	type item struct {
+		S string
+		P *int
+	}
+
+	a := newAllocator(reflect.TypeOf(item{}))
+	items := make([]*item, 100)
+	for i := range items {
+		items[i] = (*item)(a.New())
+		v := i
+		items[i].S = string(rune('a' + i%26))
+		items[i].P = &v
+	}
+
+	var ss []item
+	s := makeSliceAt(unsafe.Pointer(&ss), a, len(items))
+	for i := range items {
+		*(*item)(s.Index(i)) = *items[i]
+	}
+
+	runtime.GC()
+
+	for i := range items {
+		if *items[i].P != i || *ss[i].P != i {
+			t.Fatalf("value of the allocated item %d is corrupted", i)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package copy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NameMatcher normalizes field names, a destination and a source fields are matched if their normalized names are equal.
+type NameMatcher func(name string) string
+
+// CaseInsensitive matches field names ignoring the case, «UserID» is matched with «UserId».
+func CaseInsensitive(name string) string {
+	return strings.ToLower(name)
+}
+
+// SnakeCase normalizes field names to the snake case, «UserID», «UserId» and «user_id» are matched.
+func SnakeCase(name string) string {
+	return strings.Join(lowerWords(name), "_")
+}
+
+// KebabCase normalizes field names to the kebab case, «UserID», «UserId» and «user-id» are matched.
+func KebabCase(name string) string {
+	return strings.Join(lowerWords(name), "-")
+}
+
+// CamelCase normalizes field names to the camel case, «UserID», «UserId» and «userId» are matched.
+func CamelCase(name string) string {
+	words := lowerWords(name)
+	for i := 1; i < len(words); i++ {
+		r := []rune(words[i])
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+
+	return strings.Join(words, "")
+}
+
+// lowerWords splits the name into the lower case words.
+func lowerWords(name string) []string {
+	words := splitWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+
+	return words
+}
+
+// splitWords splits the name into words by separators «_», «-», spaces and the case changes.
+// Abbreviations are kept as one word, «HTTPServer» is split into «HTTP» and «Server».
+func splitWords(name string) []string {
+	var words []string
+
+	runes := []rune(name)
+	start := 0
+	for i, r := range runes {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+
+		if i > start && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			if !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
@@ -0,0 +1,93 @@
+package copy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNameMatchers(t *testing.T) {
+	tests := []struct {
+		name  string
+		snake string
+		kebab string
+		camel string
+	}{
+		{name: "UserID", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "UserId", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "userId", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "user_id", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "user-id", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "HTTPServer", snake: "http_server", kebab: "http-server", camel: "httpServer"},
+		{name: "created_at", snake: "created_at", kebab: "created-at", camel: "createdAt"},
+		{name: "Address2", snake: "address2", kebab: "address2", camel: "address2"},
+		{name: "__ID__", snake: "id", kebab: "id", camel: "id"},
+		{name: "", snake: "", kebab: "", camel: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SnakeCase(tt.name); got != tt.snake {
+				t.Errorf("SnakeCase() = %q, want %q", got, tt.snake)
+			}
+			if got := KebabCase(tt.name); got != tt.kebab {
+				t.Errorf("KebabCase() = %q, want %q", got, tt.kebab)
+			}
+			if got := CamelCase(tt.name); got != tt.camel {
+				t.Errorf("CamelCase() = %q, want %q", got, tt.camel)
+			}
+		})
+	}
+}
+
+func TestCopier_MatchNames(t *testing.T) {
+	type testStruct1 struct {
+		UserID    int
+		CreatedAt string
+		Name      string
+	}
+	type testStruct2 struct {
+		UserId  int
+		Created string `copy:"created_at"`
+		NAME    string
+	}
+
+	src := testStruct1{UserID: 1, CreatedAt: "today", Name: "John"}
+
+	dst := testStruct2{}
+	New(Tag("copy"), MatchNames(SnakeCase)).Copy(&dst, &src)
+	equal(t, dst, testStruct2{UserId: 1, Created: "today", NAME: "John"})
+
+	dst = testStruct2{}
+	New(MatchNames(CaseInsensitive)).Copy(&dst, &src)
+	equal(t, dst, testStruct2{UserId: 1, NAME: "John"})
+
+	dst = testStruct2{}
+	New().Copy(&dst, &src)
+	equal(t, dst, testStruct2{})
+}
+
+func TestCopier_MatchNamesAmbiguous(t *testing.T) {
+	type testStruct1 struct {
+		UserID int
+	}
+	type testStruct2 struct {
+		UserId int
+		UserID int
+	}
+
+	var errAmbiguous *AmbiguousFieldError
+
+	err := New(MatchNames(CaseInsensitive)).PrepareE(&testStruct2{}, &testStruct1{})
+	if !errors.As(err, &errAmbiguous) {
+		t.Fatalf("want AmbiguousFieldError, got %v", err)
+	}
+	equal(t, errAmbiguous.Fields, []string{"UserId", "UserID"})
+
+	err = New(MatchNames(CaseInsensitive)).PrepareE(&testStruct1{}, &testStruct2{})
+	if !errors.As(err, &errAmbiguous) {
+		t.Fatalf("want AmbiguousFieldError, got %v", err)
+	}
+
+	if err := New().PrepareE(&testStruct2{}, &testStruct1{}); err != nil {
+		t.Errorf("exact names must not be ambiguous: %v", err)
+	}
+}
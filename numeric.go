@@ -0,0 +1,117 @@
+package copy
+
+import (
+	"math"
+	"reflect"
+)
+
+// CheckedNumeric checks conversions of integers and floats to each other, including pointers and named types.
+// The overflow, the loss of the sign and the loss of the fractional part of a float converted to an integer
+// cause the NumericError, that is returned by CopyE with the path of the field. Conversions of integers
+// to floats and the precision loss of floats are not checked.
+func CheckedNumeric() Option {
+	return converters(numericConverters)
+}
+
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+var numericConverters = func() []converterFunc {
+	var funcs []converterFunc
+	add := func(f ...converterFunc) {
+		funcs = append(funcs, f...)
+	}
+
+	add(checkedFrom[int]()...)
+	add(checkedFrom[int8]()...)
+	add(checkedFrom[int16]()...)
+	add(checkedFrom[int32]()...)
+	add(checkedFrom[int64]()...)
+	add(checkedFrom[uint]()...)
+	add(checkedFrom[uint8]()...)
+	add(checkedFrom[uint16]()...)
+	add(checkedFrom[uint32]()...)
+	add(checkedFrom[uint64]()...)
+	add(checkedFrom[float32]()...)
+	add(checkedFrom[float64]()...)
+
+	return funcs
+}()
+
+// checkedFrom returns the checked converters of the Src type to other numeric types.
+func checkedFrom[Src number]() []converterFunc {
+	var funcs []converterFunc
+	add := func(f ...converterFunc) {
+		funcs = append(funcs, f...)
+	}
+
+	add(checkedConverters[int, Src]()...)
+	add(checkedConverters[int8, Src]()...)
+	add(checkedConverters[int16, Src]()...)
+	add(checkedConverters[int32, Src]()...)
+	add(checkedConverters[int64, Src]()...)
+	add(checkedConverters[uint, Src]()...)
+	add(checkedConverters[uint8, Src]()...)
+	add(checkedConverters[uint16, Src]()...)
+	add(checkedConverters[uint32, Src]()...)
+	add(checkedConverters[uint64, Src]()...)
+	add(checkedConverters[float32, Src]()...)
+	add(checkedConverters[float64, Src]()...)
+
+	return funcs
+}
+
+// checkedConverters returns the checked converters of the Src type to the Dst type, nil if the types are the same.
+func checkedConverters[Dst, Src number]() []converterFunc {
+	if reflect.TypeOf(Dst(0)) == reflect.TypeOf(Src(0)) {
+		return nil
+	}
+
+	return converterFuncs(checkedConvert[Dst, Src])
+}
+
+// half is the variable for checking whether a numeric type is a float.
+var half = 0.5
+
+func isFloat[T number]() bool {
+	return T(half) != 0
+}
+
+func isUnsigned[T number]() bool {
+	var v T
+	v--
+	return v > 0
+}
+
+// checkedConvert converts the src value to the Dst type, it returns the NumericError if the value is changed.
+func checkedConvert[Dst, Src number](src Src) (Dst, error) {
+	dst := Dst(src)
+	fail := func(err error) (Dst, error) {
+		return dst, &NumericError{Value: src, Dst: reflect.TypeOf(dst), Err: err}
+	}
+
+	switch {
+	case isFloat[Dst]():
+		if isFloat[Src]() && math.IsInf(float64(dst), 0) && !math.IsInf(float64(src), 0) {
+			return fail(ErrOverflow)
+		}
+	case src < 0 && isUnsigned[Dst]():
+		return fail(ErrSignLoss)
+	case isFloat[Src]():
+		f := float64(src)
+		t := math.Trunc(f)
+		if float64(Dst(t)) != t {
+			return fail(ErrOverflow)
+		}
+		if t != f {
+			return fail(ErrFractionLoss)
+		}
+	case Src(dst) != src || (src < 0) != (dst < 0):
+		return fail(ErrOverflow)
+	}
+
+	return dst, nil
+}
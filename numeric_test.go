@@ -0,0 +1,74 @@
+package copy
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestCheckedNumeric(t *testing.T) {
+	type Amount int64
+	type Src struct {
+		Small  int64
+		Quota  uint64
+		Price  float64
+		Ratio  float64
+		Count  *int32
+		Amount Amount
+		Total  int
+	}
+	type Dst struct {
+		Small  int8
+		Quota  int
+		Price  int32
+		Ratio  float32
+		Count  *uint16
+		Amount uint32
+		Total  float64
+	}
+
+	c := New(CheckedNumeric())
+
+	dst := Dst{}
+	c.Copy(&dst, &Src{Small: -128, Quota: 1 << 40, Price: 100, Ratio: 0.1, Count: ptr.Int32(7), Amount: 42, Total: 1 << 60})
+	equal(t, dst, Dst{Small: -128, Quota: 1 << 40, Price: 100, Ratio: 0.1, Count: ptr.UInt16(7), Amount: 42, Total: 1 << 60})
+
+	tests := []struct {
+		name  string
+		src   Src
+		path  string
+		err   error
+		value interface{}
+	}{
+		{name: "overflow", src: Src{Small: 300}, path: "Small", err: ErrOverflow, value: int64(300)},
+		{name: "negative overflow", src: Src{Small: -129}, path: "Small", err: ErrOverflow, value: int64(-129)},
+		{name: "unsigned to negative", src: Src{Quota: math.MaxUint64}, path: "Quota", err: ErrOverflow, value: uint64(math.MaxUint64)},
+		{name: "fraction", src: Src{Price: 9.99}, path: "Price", err: ErrFractionLoss, value: 9.99},
+		{name: "float to int overflow", src: Src{Price: 1e10}, path: "Price", err: ErrOverflow, value: 1e10},
+		{name: "NaN", src: Src{Price: math.NaN()}, path: "Price", err: ErrOverflow},
+		{name: "float overflow", src: Src{Ratio: math.MaxFloat64}, path: "Ratio", err: ErrOverflow, value: math.MaxFloat64},
+		{name: "pointer sign", src: Src{Count: ptr.Int32(-1)}, path: "Count", err: ErrSignLoss, value: int32(-1)},
+		{name: "named type", src: Src{Amount: -5}, path: "Amount", err: ErrSignLoss, value: int64(-5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.CopyE(&Dst{}, &tt.src)
+			var fieldErr *FieldError
+			var numErr *NumericError
+			if !errors.Is(err, tt.err) || !errors.As(err, &fieldErr) || fieldErr.Path != tt.path || !errors.As(err, &numErr) {
+				t.Fatalf("want %v of «%s», got %v", tt.err, tt.path, err)
+			}
+			if tt.value != nil && numErr.Value != tt.value {
+				t.Errorf("want the value %v, got %v", tt.value, numErr.Value)
+			}
+		})
+	}
+
+	dst = Dst{}
+	New().Copy(&dst, &Src{Small: 300})
+	if dst.Small != 44 {
+		t.Errorf("want the unchecked conversion without the option, got %d", dst.Small)
+	}
+}
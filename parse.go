@@ -0,0 +1,163 @@
+package copy
+
+import (
+	"database/sql"
+	"strconv"
+)
+
+// ParseStrings converts strings to integers, floats and bools and vice versa by strconv.
+// Pointers and sql.NullString are converted too. Strings are converted to and from sql.NullByte, sql.NullInt16,
+// sql.NullInt32, sql.NullInt64, sql.NullFloat64 and sql.NullBool, an empty string is converted to the invalid value.
+// Parse errors are returned by CopyE and cause panic of Copy.
+func ParseStrings() Option {
+	return converters(stringConverters)
+}
+
+var stringConverters = func() []converterFunc {
+	var funcs []converterFunc
+	add := func(f ...converterFunc) {
+		funcs = append(funcs, f...)
+	}
+
+	add(parseConverters(parseInt[int](strconv.IntSize), formatInt[int])...)
+	add(parseConverters(parseInt[int8](8), formatInt[int8])...)
+	add(parseConverters(parseInt[int16](16), formatInt[int16])...)
+	add(parseConverters(parseInt[int32](32), formatInt[int32])...)
+	add(parseConverters(parseInt[int64](64), formatInt[int64])...)
+	add(parseConverters(parseUint[uint](strconv.IntSize), formatUint[uint])...)
+	add(parseConverters(parseUint[uint8](8), formatUint[uint8])...)
+	add(parseConverters(parseUint[uint16](16), formatUint[uint16])...)
+	add(parseConverters(parseUint[uint32](32), formatUint[uint32])...)
+	add(parseConverters(parseUint[uint64](64), formatUint[uint64])...)
+	add(parseConverters(parseFloat[float32](32), formatFloat[float32](32))...)
+	add(parseConverters(parseFloat[float64](64), formatFloat[float64](64))...)
+	add(parseConverters(strconv.ParseBool, formatBool)...)
+
+	// sql.Null types.
+	add(parseConverters(parseNull(parseUint[uint8](8), func(v uint8) sql.NullByte {
+		return sql.NullByte{Byte: v, Valid: true}
+	}), func(v sql.NullByte) (string, error) {
+		return formatNull(v.Valid, v.Byte, formatUint[uint8])
+	})...)
+	add(parseConverters(parseNull(parseInt[int16](16), func(v int16) sql.NullInt16 {
+		return sql.NullInt16{Int16: v, Valid: true}
+	}), func(v sql.NullInt16) (string, error) {
+		return formatNull(v.Valid, v.Int16, formatInt[int16])
+	})...)
+	add(parseConverters(parseNull(parseInt[int32](32), func(v int32) sql.NullInt32 {
+		return sql.NullInt32{Int32: v, Valid: true}
+	}), func(v sql.NullInt32) (string, error) {
+		return formatNull(v.Valid, v.Int32, formatInt[int32])
+	})...)
+	add(parseConverters(parseNull(parseInt[int64](64), func(v int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: v, Valid: true}
+	}), func(v sql.NullInt64) (string, error) {
+		return formatNull(v.Valid, v.Int64, formatInt[int64])
+	})...)
+	add(parseConverters(parseNull(parseFloat[float64](64), func(v float64) sql.NullFloat64 {
+		return sql.NullFloat64{Float64: v, Valid: true}
+	}), func(v sql.NullFloat64) (string, error) {
+		return formatNull(v.Valid, v.Float64, formatFloat[float64](64))
+	})...)
+	add(parseConverters(parseNull(strconv.ParseBool, func(v bool) sql.NullBool {
+		return sql.NullBool{Bool: v, Valid: true}
+	}), func(v sql.NullBool) (string, error) {
+		return formatNull(v.Valid, v.Bool, formatBool)
+	})...)
+
+	return funcs
+}()
+
+// parseConverters returns the converters of strings to values and values to strings,
+// including pointers and sql.NullString.
+func parseConverters[T any](parse func(string) (T, error), format func(T) (string, error)) []converterFunc {
+	funcs := append(converterFuncs(parse), converterFuncs(format)...)
+	funcs = append(funcs, converterFuncs(func(s sql.NullString) (T, error) {
+		if !s.Valid {
+			var zero T
+			return zero, nil
+		}
+		return parse(s.String)
+	})...)
+	funcs = append(funcs, converterFuncs(func(v T) (sql.NullString, error) {
+		s, err := format(v)
+		return sql.NullString{String: s, Valid: err == nil}, err
+	})...)
+
+	return funcs
+}
+
+type signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+type float interface {
+	~float32 | ~float64
+}
+
+func parseInt[T signed](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseInt(s, 10, bitSize)
+		return T(v), err
+	}
+}
+
+func formatInt[T signed](v T) (string, error) {
+	return strconv.FormatInt(int64(v), 10), nil
+}
+
+func parseUint[T unsigned](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseUint(s, 10, bitSize)
+		return T(v), err
+	}
+}
+
+func formatUint[T unsigned](v T) (string, error) {
+	return strconv.FormatUint(uint64(v), 10), nil
+}
+
+func parseFloat[T float](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseFloat(s, bitSize)
+		return T(v), err
+	}
+}
+
+func formatFloat[T float](bitSize int) func(T) (string, error) {
+	return func(v T) (string, error) {
+		return strconv.FormatFloat(float64(v), 'g', -1, bitSize), nil
+	}
+}
+
+func formatBool(v bool) (string, error) {
+	return strconv.FormatBool(v), nil
+}
+
+// parseNull returns the parser of the sql.Null type, an empty string is the invalid value.
+func parseNull[T, N any](parse func(string) (T, error), valid func(T) N) func(string) (N, error) {
+	return func(s string) (N, error) {
+		var null N
+		if s == "" {
+			return null, nil
+		}
+		v, err := parse(s)
+		if err != nil {
+			return null, err
+		}
+		return valid(v), nil
+	}
+}
+
+// formatNull formats the valid value of the sql.Null type, the invalid value is an empty string.
+func formatNull[T any](valid bool, v T, format func(T) (string, error)) (string, error) {
+	if !valid {
+		return "", nil
+	}
+
+	return format(v)
+}
@@ -0,0 +1,66 @@
+package copy
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestParseStrings(t *testing.T) {
+	type Query struct {
+		Page    string
+		Limit   *string
+		Price   string
+		Active  string
+		Age     string
+		Score   string
+		Deleted sql.NullString
+		Level   string
+		Flags   string
+	}
+	type Params struct {
+		Page    int
+		Limit   *uint16
+		Price   float64
+		Active  bool
+		Age     sql.NullInt64
+		Score   *float32
+		Deleted bool
+		Level   sql.NullInt16
+		Flags   sql.NullByte
+	}
+
+	c := New(ParseStrings())
+
+	dst := Params{}
+	c.Copy(&dst, &Query{Page: "2", Limit: ptr.String("50"), Price: "9.99", Active: "true", Age: "33", Score: "0.5", Deleted: sql.NullString{String: "1", Valid: true}, Level: "-3", Flags: "7"})
+	equal(t, dst, Params{Page: 2, Limit: ptr.UInt16(50), Price: 9.99, Active: true, Age: sql.NullInt64{Int64: 33, Valid: true}, Score: ptr.Float32(0.5), Deleted: true,
+		Level: sql.NullInt16{Int16: -3, Valid: true}, Flags: sql.NullByte{Byte: 7, Valid: true}})
+
+	query := Query{}
+	c.Copy(&query, &dst)
+	equal(t, query, Query{Page: "2", Limit: ptr.String("50"), Price: "9.99", Active: "true", Age: "33", Score: "0.5", Deleted: sql.NullString{String: "true", Valid: true}, Level: "-3", Flags: "7"})
+
+	dst = Params{}
+	c.Copy(&dst, &Query{Page: "1", Price: "1", Active: "false", Score: "1"})
+	equal(t, dst, Params{Page: 1, Price: 1, Score: ptr.Float32(1)})
+
+	err := c.CopyE(&dst, &Query{Page: "two", Price: "1", Active: "false", Score: "1"})
+	var numErr *strconv.NumError
+	var fieldErr *FieldError
+	if !errors.As(err, &numErr) || !errors.As(err, &fieldErr) || fieldErr.Path != "Page" {
+		t.Errorf("want the parse error of «Page», got %v", err)
+	}
+
+	err = c.CopyE(&dst, &Query{Page: "1", Limit: ptr.String("70000"), Price: "1", Active: "false", Score: "1"})
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("want the range error, got %v", err)
+	}
+
+	if err := New().PrepareE(&dst, &Query{}); err == nil {
+		t.Error("strings must not be parsed without the option")
+	}
+}
@@ -0,0 +1,105 @@
+package copy
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/gotidy/copy/internal/cache"
+)
+
+// isPath reports whether the field name is the dotted path to a nested field, for example «Address.City».
+func isPath(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// fieldPath is the path to a nested field, pointers to structs along the path are dereferenced.
+type fieldPath struct {
+	// leaf is the last field, its name is the path and the offset is relative to the last struct.
+	leaf cache.Field
+	hops []pathHop
+}
+
+type pathHop struct {
+	offset uintptr
+	deref  bool // The field at the offset is a pointer to the next struct.
+	alloc  allocator
+}
+
+func fieldPathOf(f cache.Field) fieldPath {
+	return fieldPath{leaf: f, hops: []pathHop{{offset: f.Offset}}}
+}
+
+// field returns the field if the path is the field of the struct itself.
+func (p fieldPath) field() (cache.Field, bool) {
+	return p.leaf, len(p.hops) == 1
+}
+
+// get returns the pointer to the field of the struct at ptr or nil if a pointer along the path is nil.
+// Nil pointers are allocated if alloc is true.
+func (p fieldPath) get(ptr unsafe.Pointer, alloc bool) unsafe.Pointer {
+	for _, h := range p.hops {
+		ptr = unsafe.Pointer(uintptr(ptr) + h.offset)
+		if !h.deref {
+			continue
+		}
+
+		next := (*unsafe.Pointer)(ptr)
+		if *next == nil {
+			if !alloc {
+				return nil
+			}
+			*next = h.alloc.New()
+		}
+		ptr = *next
+	}
+
+	return ptr
+}
+
+// isZeroAt reports whether the memory of the size at ptr is zeroed.
+func isZeroAt(ptr unsafe.Pointer, size uintptr) bool {
+	for _, b := range unsafe.Slice((*byte)(ptr), size) {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolvePath resolves the dotted path to the nested field of the struct type.
+// Names in the path are the field names or the names set by the tag.
+// The type must be a struct.
+func (c *Copiers) resolvePath(t reflect.Type, path string) (fieldPath, bool) {
+	if f, ok := c.cache.GetByType(t).FieldByName(path); ok {
+		return fieldPathOf(f), true
+	}
+
+	var p fieldPath
+
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		if t.Kind() != reflect.Struct {
+			return fieldPath{}, false
+		}
+		f, ok := c.cache.GetByType(t).FieldByName(name)
+		if !ok {
+			return fieldPath{}, false
+		}
+
+		hop := pathHop{offset: f.Offset}
+		t = f.Type
+		if i < len(names)-1 && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+			hop.deref = true
+			hop.alloc = newAllocator(t)
+		}
+		p.hops = append(p.hops, hop)
+		p.leaf = f
+	}
+	p.leaf.Name = path
+	p.leaf.Offset = 0
+
+	return p, true
+}
@@ -0,0 +1,84 @@
+package copy
+
+import (
+	"testing"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestCopier_Path(t *testing.T) {
+	type Country struct {
+		Code string
+	}
+	type Address struct {
+		City    string
+		Zip     int
+		Country *Country
+	}
+	type Nested struct {
+		Name    string
+		Address *Address
+	}
+	type Flat struct {
+		Name        string
+		City        string  `copy:"Address.City"`
+		Zip         int64   `copy:"Address.Zip"`
+		CountryCode *string `copy:"Address.Country.Code"`
+	}
+
+	c := New(Tag("copy"))
+
+	// Flattening.
+	src := Nested{Name: "John", Address: &Address{City: "Paris", Zip: 75001, Country: &Country{Code: "FR"}}}
+	flat := Flat{}
+	c.Copy(&flat, &src)
+	equal(t, flat, Flat{Name: "John", City: "Paris", Zip: 75001, CountryCode: ptr.String("FR")})
+
+	flat = Flat{City: "London", Zip: 1, CountryCode: ptr.String("GB")}
+	c.Copy(&flat, &Nested{Name: "John"})
+	equal(t, flat, Flat{Name: "John"})
+
+	// Unflattening.
+	nested := Nested{}
+	c.Copy(&nested, &Flat{Name: "John", City: "Paris", Zip: 75001})
+	equal(t, nested, Nested{Name: "John", Address: &Address{City: "Paris", Zip: 75001}})
+
+	nested = Nested{}
+	c.Copy(&nested, &Flat{Name: "John", CountryCode: ptr.String("FR")})
+	equal(t, nested, Nested{Name: "John", Address: &Address{Country: &Country{Code: "FR"}}})
+
+	address := nested.Address
+	c.Copy(&nested, &Flat{City: "Berlin"})
+	if nested.Address != address {
+		t.Error("the existing destination struct must be reused")
+	}
+	equal(t, nested, Nested{Address: &Address{City: "Berlin", Country: &Country{}}})
+}
+
+func TestCopiers_MapPath(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type Nested struct {
+		Address Address
+	}
+	type Flat struct {
+		Town string
+	}
+
+	c := New()
+	c.Map(&Flat{}, &Nested{}, Field("Town", "Address.City"))
+	c.Map(&Nested{}, &Flat{}, Field("Address.City", "Town"))
+
+	flat := Flat{}
+	c.Copy(&flat, &Nested{Address: Address{City: "Paris"}})
+	equal(t, flat, Flat{Town: "Paris"})
+
+	nested := Nested{}
+	c.Copy(&nested, &Flat{Town: "Paris"})
+	equal(t, nested, Nested{Address: Address{City: "Paris"}})
+
+	if err := c.MapE(&Flat{}, &Nested{}, Field("Town", "Address.Street")); err == nil {
+		t.Error("the unknown path must cause the error")
+	}
+}
@@ -0,0 +1,162 @@
+package copy
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Strategy is the way a field is copied.
+type Strategy int
+
+const (
+	// SkippedStrategy means that the types of fields are not supported and the field is skipped by the Skip option.
+	SkippedStrategy Strategy = iota
+	// IgnoredStrategy means that the field is ignored by the mapping or the readonly and writeonly tag options.
+	IgnoredStrategy
+	// UnmappedStrategy means that the destination field has no source field.
+	UnmappedStrategy
+	// MemcopyStrategy means that the memory of the field is copied as is.
+	MemcopyStrategy
+	// FuncStrategy means that the field is copied by the copy function, a builtin conversion or a custom converter.
+	FuncStrategy
+	// CopierStrategy means that the field is copied by the nested copier.
+	CopierStrategy
+	// DeepStrategy means that the field is cloned by the Deep option.
+	DeepStrategy
+	// DynamicStrategy means that the copier is chosen by the dynamic type of the interface value.
+	DynamicStrategy
+	// DefaultStrategy means that the destination field has no source field and it is set to the default value.
+	DefaultStrategy
+	// TextStrategy means that the field is copied by the encoding.TextMarshaler or encoding.TextUnmarshaler methods.
+	TextStrategy
+)
+
+var strategyNames = [...]string{
+	SkippedStrategy:  "skipped",
+	IgnoredStrategy:  "ignored",
+	UnmappedStrategy: "unmapped",
+	MemcopyStrategy:  "memcopy",
+	FuncStrategy:     "func",
+	CopierStrategy:   "copier",
+	DeepStrategy:     "deep",
+	DynamicStrategy:  "dynamic",
+	DefaultStrategy:  "default",
+	TextStrategy:     "text",
+}
+
+func (s Strategy) String() string {
+	if s >= 0 && int(s) < len(strategyNames) {
+		return strategyNames[s]
+	}
+
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+// PlanField is the decision made for a destination field.
+type PlanField struct {
+	// Dst and Src are the names or the dotted paths of fields. Src is empty if the destination field has no source field.
+	Dst      string
+	Src      string
+	DstType  reflect.Type
+	SrcType  reflect.Type
+	Strategy Strategy
+	// Converter is the name of the copy function or the type of the nested copier.
+	Converter string
+}
+
+func (f PlanField) String() string {
+	var b strings.Builder
+
+	b.WriteString(f.Dst)
+	if f.Src != "" {
+		b.WriteString(" <- ")
+		b.WriteString(f.Src)
+	}
+	b.WriteString(": ")
+	b.WriteString(f.Strategy.String())
+	if f.Converter != "" {
+		b.WriteString(" ")
+		b.WriteString(f.Converter)
+	}
+
+	return b.String()
+}
+
+// Plan is the decisions made for the pair of struct types.
+type Plan struct {
+	Dst    reflect.Type
+	Src    reflect.Type
+	Fields []PlanField
+}
+
+// String returns the plan in the human-readable form, one field per line.
+func (p Plan) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s <- %s\n", p.Dst, p.Src)
+	for _, f := range p.Fields {
+		b.WriteString("\t")
+		b.WriteString(f.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// funcName returns the short name of the function.
+func funcName(f interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	name := fn.Name()
+	if idx := strings.LastIndex(name, "/"); idx != -1 {
+		name = name[idx+1:]
+	}
+
+	return name
+}
+
+// Explain returns the plan of copying the pair of struct types: how every field is copied.
+// Dst and src each must be a pointer to struct.
+//
+//	fmt.Println(c.Explain(&Employee{}, &User{}))
+func (c *Copiers) Explain(dst, src interface{}) Plan {
+	plan, err := c.ExplainE(dst, src)
+	if err != nil {
+		panic(err)
+	}
+
+	return plan
+}
+
+// ExplainE is like Explain but returns an error instead of panic.
+func (c *Copiers) ExplainE(dst, src interface{}) (Plan, error) {
+	copier, err := c.GetE(dst, src)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	structCopier, ok := copier.(*StructCopier)
+	if !ok {
+		return Plan{}, &UnsupportedTypesError{Dst: reflect.TypeOf(dst).Elem(), Src: reflect.TypeOf(src).Elem()}
+	}
+
+	fields := make([]PlanField, len(structCopier.plan))
+	copy(fields, structCopier.plan)
+
+	return Plan{Dst: structCopier.dstType, Src: structCopier.srcType, Fields: fields}, nil
+}
+
+// Explain returns the plan of copying the pair of struct types by the default Copiers.
+func Explain(dst, src interface{}) Plan {
+	return defaultCopier.Explain(dst, src)
+}
+
+// ExplainE is like Explain but returns an error instead of panic.
+func ExplainE(dst, src interface{}) (Plan, error) {
+	return defaultCopier.ExplainE(dst, src)
+}
@@ -0,0 +1,79 @@
+package copy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCopiers_Explain(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type Buffer [512]byte
+	type testStruct1 struct {
+		Buf      Buffer
+		Name     string
+		Age      int
+		Address  Address
+		Tags     []string
+		Ch       chan int
+		Password string
+		Any      interface{}
+	}
+	type testStruct2 struct {
+		Buf      Buffer
+		Name     string
+		Age      int64
+		Address  *Address
+		Tags     []string
+		Ch       chan string
+		Password string
+		Any      int
+		Lang     string `copy:",default=en"`
+		Email    string
+	}
+
+	c := New(Tag("copy"), Skip())
+	c.Map(&testStruct2{}, &testStruct1{}, Ignore("Password"))
+
+	plan := c.Explain(&testStruct2{}, &testStruct1{})
+	strategies := map[string]Strategy{}
+	for _, f := range plan.Fields {
+		strategies[f.Dst] = f.Strategy
+	}
+	equal(t, strategies, map[string]Strategy{
+		"Buf":      MemcopyStrategy,
+		"Name":     FuncStrategy,
+		"Age":      FuncStrategy,
+		"Address":  CopierStrategy,
+		"Tags":     FuncStrategy,
+		"Ch":       SkippedStrategy,
+		"Any":      DynamicStrategy,
+		"Password": IgnoredStrategy,
+		"Lang":     DefaultStrategy,
+		"Email":    UnmappedStrategy,
+	})
+
+	s := plan.String()
+	for _, line := range []string{
+		"Buf <- Buf: memcopy",
+		"Name <- Name: func funcs.copyStringToString",
+		"Age <- Age: func funcs.copyIntToInt64",
+		"Address <- Address: copier *copy.ValueToPValueCopier",
+		"Email: unmapped",
+	} {
+		if !strings.Contains(s, line) {
+			t.Errorf("the plan does not contain %q:\n%s", line, s)
+		}
+	}
+
+	plan = New(Deep()).Explain(&testStruct2{}, &testStruct2{})
+	if plan.Fields[4].Dst != "Tags" || plan.Fields[4].Strategy != DeepStrategy {
+		t.Errorf("want the deep strategy of «Tags», got %s", plan.Fields[4])
+	}
+
+	if _, err := c.ExplainE(new(int), new(int)); !errors.As(err, new(*UnsupportedTypesError)) {
+		t.Errorf("want UnsupportedTypesError, got %v", err)
+	}
+}
@@ -1,7 +1,6 @@
 package copy
 
 import (
-	"fmt"
 	"reflect"
 	"unsafe"
 )
@@ -10,8 +9,8 @@ type SliceCopier struct {
 	BaseCopier
 
 	copier  func(dst, src unsafe.Pointer)
-	dstSize uintptr // Size of the destination element
-	srcSize uintptr // Size of the source element
+	dstElem allocator // Allocator of the destination elements
+	srcSize uintptr   // Size of the source element
 }
 
 func NewSliceCopier(c *Copiers) *SliceCopier {
@@ -19,38 +18,46 @@ func NewSliceCopier(c *Copiers) *SliceCopier {
 	return copier
 }
 
-func (c *SliceCopier) init(dst, src reflect.Type) {
+func (c *SliceCopier) init(dst, src reflect.Type) (err error) {
 	c.BaseCopier.init(dst, src)
 
-	c.copier = c.getCopierFunc(dst.Elem(), src.Elem(), 0, 0)
+	c.copier, c.throwing, err = c.getCopierFunc(dst.Elem(), src.Elem(), 0, 0)
+	if err != nil {
+		return fieldError("[]", dst.Elem(), src.Elem(), err)
+	}
 	if c.copier == nil && !c.options.Skip {
-		panic(fmt.Errorf(`slice element of type «%s» is not assignable to slice element of type «%s»`, src.String(), dst.String()))
+		return fieldError("[]", dst.Elem(), src.Elem(), &UnsupportedTypesError{Dst: dst.Elem(), Src: src.Elem()})
+	}
+	if c.throwing {
+		c.copier = withPath(c.copier, "[]", dst.Elem(), src.Elem())
 	}
-	c.dstSize = dst.Elem().Size()
+	c.dstElem = newAllocator(dst.Elem())
 	c.srcSize = src.Elem().Size()
+
+	return nil
 }
 
 // Copy copies the contents of src into dst. Dst and src each must be a pointer to struct.
 func (c *SliceCopier) Copy(dst, src interface{}) {
-	dstType, dstPtr := DataOf(dst)
-	srcType, srcPtr := DataOf(src)
-
-	if c.src.Check(srcType) {
-		panic("source expected type " + c.src.Name + ", but has " + reflect.TypeOf(src).String())
-	}
-	if c.dst.Check(dstType) {
-		panic("destination expected type " + c.dst.Name + ", but has " + reflect.TypeOf(dst).String())
-	}
+	mustCopy(c, dst, src)
+}
 
-	c.copy(dstPtr, srcPtr)
+// CopyE is like Copy but returns an error instead of panic.
+func (c *SliceCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
 }
 
 func (c *SliceCopier) copy(dst, src unsafe.Pointer) {
 	if c.copier == nil {
 		return
 	}
+
 	srcSlice := sliceAt(src, c.srcSize)
-	dstSlice := makeSliceAt(dst, c.dstSize, srcSlice.Len)
+	// Destination can share the backing array with the source after a shallow copying.
+	if dstSlice := sliceAt(dst, c.dstElem.size); dstSlice.data == srcSlice.data {
+		*(*[]struct{})(dst) = nil
+	}
+	dstSlice := makeSliceAt(dst, c.dstElem, srcSlice.Len)
 
 	for i := 0; i < srcSlice.Len; i++ {
 		c.copier(dstSlice.Index(i), srcSlice.Index(i))
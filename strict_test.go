@@ -0,0 +1,57 @@
+package copy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCopier_Strict(t *testing.T) {
+	type Person struct {
+		Name    string
+		Surname string
+	}
+	type Address struct {
+		City string
+		Zip  string
+	}
+	type testStruct1 struct {
+		Name    string
+		Surname string
+		City    string `copy:"Address.City"`
+	}
+	type testStruct2 struct {
+		Person
+		Address  Address
+		Email    string
+		Phone    string
+		Lang     string `copy:",default=en"`
+		ID       int    `copy:",readonly"`
+		Password string
+	}
+
+	var unmapped *UnmappedFieldsError
+
+	err := New(Tag("copy"), Strict()).PrepareE(&testStruct2{}, &testStruct1{})
+	if !errors.As(err, &unmapped) {
+		t.Fatalf("want UnmappedFieldsError, got %v", err)
+	}
+	// Address is filled partially by the dotted path, so its unfilled nested fields are reported.
+	equal(t, unmapped.Fields, []string{"Address.Zip", "Email", "Phone", "Password"})
+
+	c := New(Tag("copy"), Strict())
+	c.Map(&testStruct2{}, &testStruct1{}, Field("Email", "Name"), Field("Phone", "Name"), Ignore("Password"), Ignore("Address.Zip"))
+	if err := c.PrepareE(&testStruct2{}, &testStruct1{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Per pair check.
+	c = New(Tag("copy"))
+	if err := c.PrepareE(&testStruct2{}, &testStruct1{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	c.Map(&testStruct2{}, &testStruct1{}, Exhaustive(), Ignore("Password"))
+	if err := c.PrepareE(&testStruct2{}, &testStruct1{}); !errors.As(err, &unmapped) {
+		t.Fatalf("want UnmappedFieldsError, got %v", err)
+	}
+	equal(t, unmapped.Fields, []string{"Address.Zip", "Email", "Phone"})
+}
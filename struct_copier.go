@@ -4,19 +4,30 @@ package copy
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"unsafe"
 
-	"github.com/gotidy/copy/funcs"
 	"github.com/gotidy/copy/internal/cache"
 )
 
 type copierFunc = func(dst, src unsafe.Pointer)
 
+// fieldPair is a pair of the matched destination and source fields.
+type fieldPair struct {
+	dst cache.Field
+	src cache.Field
+}
+
 // StructCopier fills a destination from source.
 type StructCopier struct {
 	BaseCopier
 
 	copiers []copierFunc
+	fields  []fieldPair // Fields copied by the copiers with the same index.
+	plan    []PlanField
+
+	before, after bool // The destination implements BeforeCopier and AfterCopier.
+	fieldsThrow   bool // Copiers of fields can throw errors.
 }
 
 func NewStructCopier(c *Copiers) *StructCopier {
@@ -24,77 +35,419 @@ func NewStructCopier(c *Copiers) *StructCopier {
 	return copier
 }
 
-func (c *StructCopier) init(dst, src reflect.Type) {
+func (c *StructCopier) init(dst, src reflect.Type) error {
 	c.BaseCopier.init(dst, src)
+	c.before, c.after = hooks(dst)
+
+	if err := c.checkTransforms(dst, src); err != nil {
+		return err
+	}
 
 	srcStruct := c.cache.GetByType(src)
 	dstStruct := c.cache.GetByType(dst)
+	if err := checkOptions(dstStruct, srcStruct); err != nil {
+		return err
+	}
+
+	mapping := c.mapping(dst, src)
 
 	for i := 0; i < srcStruct.NumField(); i++ {
 		srcField := srcStruct.Field(i)
-		if dstField, ok := dstStruct.FieldByName(srcField.Name); ok {
-			if f := c.fieldCopier(dstField, srcField); f != nil {
-				c.copiers = append(c.copiers, f)
+		dstFields := dstStruct.FieldsByName(srcField.Name)
+		if len(dstFields) == 0 {
+			// The source field is tagged by the path to the nested destination field.
+			if isPath(srcField.Name) && !mapping.skip(srcField.Name) {
+				if dstPath, ok := c.resolvePath(dst, srcField.Name); ok {
+					if err := c.addPath(dstPath, fieldPathOf(srcField)); err != nil {
+						return err
+					}
+				}
+			}
+			continue
+		}
+		if mapping.skip(dstFields[0].Name) {
+			continue
+		}
+		if err := ambiguous(dst, dstFields); err != nil {
+			return fieldError(srcField.Name, dst, src, err)
+		}
+		if err := ambiguous(src, srcStruct.FieldsByName(srcField.Name)); err != nil {
+			return fieldError(dstFields[0].Name, dst, src, err)
+		}
+
+		if err := c.addField(dstFields[0], srcField); err != nil {
+			return err
+		}
+	}
+
+	// The destination fields are tagged by the paths to the nested source fields.
+	for i := 0; i < dstStruct.NumField(); i++ {
+		dstField := dstStruct.Field(i)
+		if !isPath(dstField.Name) || mapping.skip(dstField.Name) || len(srcStruct.FieldsByName(dstField.Name)) > 0 {
+			continue
+		}
+		if srcPath, ok := c.resolvePath(src, dstField.Name); ok {
+			if err := c.addPath(fieldPathOf(dstField), srcPath); err != nil {
+				return err
 			}
 		}
 	}
+
+	if c.options.Flatten {
+		if err := c.flatten(dstStruct, srcStruct, mapping); err != nil {
+			return err
+		}
+	}
+
+	if err := c.mapFields(mapping); err != nil {
+		return err
+	}
+
+	if err := c.unmatched(dstStruct, mapping); err != nil {
+		return err
+	}
+	c.throwing = c.before || c.after || c.fieldsThrow
+
+	return nil
 }
 
-// Copy copies the contents of src into dst. Dst and src each must be a pointer to struct.
-func (c *StructCopier) Copy(dst, src interface{}) {
-	dstType, dstPtr := DataOf(dst)
-	srcType, srcPtr := DataOf(src)
+// mapFields adds the copiers of the fields mapped explicitly.
+func (c *StructCopier) mapFields(mapping *fieldMapping) error {
+	if mapping == nil {
+		return nil
+	}
 
-	if c.src.Check(srcType) {
-		panic("source expected type " + c.src.Name + ", but has " + reflect.TypeOf(src).String())
+	for _, m := range mapping.fields {
+		if mapping.ignore[m.dst] {
+			continue
+		}
+		dstPath, _ := c.resolvePath(c.dstType, m.dst)
+		srcPath, _ := c.resolvePath(c.srcType, m.src)
+		if err := c.addPath(dstPath, srcPath); err != nil {
+			return err
+		}
 	}
-	if c.dst.Check(dstType) {
-		panic("destination expected type " + c.dst.Name + ", but has " + reflect.TypeOf(dst).String())
+
+	return nil
+}
+
+// unmatched checks the required destination fields and sets the default values of the fields without source fields.
+// In the strict mode all destination fields without source fields are reported.
+func (c *StructCopier) unmatched(dstStruct cache.Struct, mapping *fieldMapping) error {
+	matched, partial := c.matched(dstStruct)
+	// The fields with source fields, that are not copied, are planned already.
+	for _, f := range c.plan {
+		matched[f.Dst] = true
+	}
+
+	var unmapped []string
+	for _, dstField := range dstStruct.Fields {
+		if mapping != nil && mapping.ignore[dstField.Name] {
+			c.plan = append(c.plan, PlanField{Dst: dstField.Name, DstType: dstField.Type, Strategy: IgnoredStrategy})
+			continue
+		}
+		if matched[dstField.Name] || dstField.ReadOnly || mapping.skip(dstField.Name) {
+			continue
+		}
+		// Nested fields of the struct filled by dotted paths are checked instead of the struct.
+		if partial[dstField.Name] {
+			if !dstField.Anonymous {
+				for _, f := range c.unmatchedPaths(dstField.Type, dstField.Name, matched, partial, mapping) {
+					unmapped = append(unmapped, f.Name)
+					c.plan = append(c.plan, PlanField{Dst: f.Name, DstType: f.Type, Strategy: UnmappedStrategy})
+				}
+			}
+			continue
+		}
+		if dstField.Required {
+			return fieldError(dstField.Name, dstField.Type, nil, ErrRequired)
+		}
+		if dstField.HasDefault {
+			set, err := defaultSetter(dstField)
+			if err != nil {
+				return fieldError(dstField.Name, dstField.Type, nil, err)
+			}
+			offset := dstField.Offset
+			c.copiers = append(c.copiers, func(dst, src unsafe.Pointer) {
+				set(unsafe.Pointer(uintptr(dst) + offset))
+			})
+			c.fields = append(c.fields, fieldPair{dst: dstField})
+			c.plan = append(c.plan, PlanField{Dst: dstField.Name, DstType: dstField.Type, Strategy: DefaultStrategy})
+			continue
+		}
+		// Fields of the embedded struct are checked instead of the struct.
+		if !dstField.Anonymous {
+			unmapped = append(unmapped, dstField.Name)
+			c.plan = append(c.plan, PlanField{Dst: dstField.Name, DstType: dstField.Type, Strategy: UnmappedStrategy})
+		}
 	}
 
-	c.copy(dstPtr, srcPtr)
+	if len(unmapped) > 0 && (c.options.Strict || mapping.strict()) {
+		return &UnmappedFieldsError{Dst: c.dstType, Src: c.srcType, Fields: unmapped}
+	}
+
+	return nil
 }
 
-func (c *StructCopier) copy(dst, src unsafe.Pointer) {
-	for _, c := range c.copiers {
-		c(dst, src)
+// flatten matches the fields that are not matched by names with the nested fields by concatenated names.
+func (c *StructCopier) flatten(dstStruct, srcStruct cache.Struct, mapping *fieldMapping) error {
+	matched := make(map[string]bool, len(c.fields))
+	for _, f := range c.fields {
+		matched[f.dst.Name] = true
 	}
+
+	// Flattening: «CustomerName» is filled from «Customer.Name».
+	for _, dstField := range dstStruct.Fields {
+		if matched[dstField.Name] || isPath(dstField.Name) || mapping.skip(dstField.Name) {
+			continue
+		}
+		if path, ok := c.flattenPath(c.srcType, dstField.Name); ok {
+			srcPath, _ := c.resolvePath(c.srcType, path)
+			if err := c.addPath(fieldPathOf(dstField), srcPath); err != nil {
+				return err
+			}
+			matched[dstField.Name] = true
+		}
+	}
+
+	// Unflattening: «Customer.Name» is filled from «CustomerName».
+	for _, srcField := range srcStruct.Fields {
+		if isPath(srcField.Name) || len(dstStruct.FieldsByName(srcField.Name)) > 0 {
+			continue
+		}
+		path, ok := c.flattenPath(c.dstType, srcField.Name)
+		if !ok || matched[path] || mapping.skip(path) {
+			continue
+		}
+		// The nested struct filled from the source struct is not changed.
+		if root := path[:strings.Index(path, ".")]; matched[root] || mapping.skip(root) {
+			continue
+		}
+		dstPath, _ := c.resolvePath(c.dstType, path)
+		if err := c.addPath(dstPath, fieldPathOf(srcField)); err != nil {
+			return err
+		}
+		matched[path] = true
+	}
+
+	return nil
 }
 
-func (c *StructCopier) fieldCopier(dst, src cache.Field) copierFunc {
-	// TODO: Unify with the slice copier, may be do it inside Copiers.get
-	dstOffset := dst.Offset
-	srcOffset := src.Offset
-	copierFunc := funcs.Get(dst.Type, src.Type)
-	if copierFunc != nil {
-		return func(dstPtr, srcPtr unsafe.Pointer) {
-			copierFunc(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+// matched returns names of the destination fields that are filled by the copiers, the fields of the matched
+// embedded struct are matched too. Partial are names of the structs, whose nested fields are filled by dotted paths.
+func (c *StructCopier) matched(dstStruct cache.Struct) (matched, partial map[string]bool) {
+	matched = make(map[string]bool, len(c.fields))
+	partial = make(map[string]bool)
+	for _, f := range c.fields {
+		matched[f.dst.Name] = true
+		for name := f.dst.Name; isPath(name); {
+			name = name[:strings.LastIndex(name, ".")]
+			partial[name] = true
+		}
+	}
+	for _, f := range dstStruct.Fields {
+		if f.ParentName != "" && (matched[f.ParentName] || matched[f.ParentName+"."+f.Name]) {
+			matched[f.Name] = true
 		}
 	}
 
-	// same type -> same type
-	if src.Type == dst.Type {
-		size := int(src.Type.Size())
+	return matched, partial
+}
 
-		return func(dstPtr, srcPtr unsafe.Pointer) {
-			// More safe and independent from internal structs
-			// src := reflect.NewAt(src.Type, unsafe.Pointer(uintptr(srcPtr)+src.Offset)).Elem()
-			// dst := reflect.NewAt(dst.Type, unsafe.Pointer(uintptr(dstPtr)+dst.Offset)).Elem()
-			// dst.Set(src)
-			memcopy(unsafe.Pointer(uintptr(dstPtr)+dst.Offset), unsafe.Pointer(uintptr(srcPtr)+src.Offset), size)
+// unmatchedPaths returns the nested fields of the struct at the path, that are not filled. Names of fields are dotted paths.
+func (c *StructCopier) unmatchedPaths(t reflect.Type, path string, matched, partial map[string]bool, mapping *fieldMapping) []cache.Field {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var paths []cache.Field
+	for _, f := range c.cache.GetByType(t).Fields {
+		name := path + "." + f.Name
+		switch {
+		case matched[name] || f.ReadOnly || f.Anonymous || mapping.skip(name):
+		case f.ParentName != "" && (matched[path+"."+f.ParentName] || matched[path+"."+f.ParentName+"."+f.Name]):
+		case partial[name]:
+			paths = append(paths, c.unmatchedPaths(f.Type, name, matched, partial, mapping)...)
+		default:
+			f.Name = name
+			paths = append(paths, f)
 		}
 	}
 
-	copier, err := c.get(dst.Type, src.Type)
-	if err == nil {
-		return func(dstPtr, srcPtr unsafe.Pointer) {
-			copier.copy(unsafe.Pointer(uintptr(dstPtr)+dst.Offset), unsafe.Pointer(uintptr(srcPtr)+src.Offset))
+	return paths
+}
+
+// addField adds the copier of the src field to the dst field.
+func (c *StructCopier) addField(dst, src cache.Field) error {
+	f, err := c.fieldCopier(dst, src)
+	if err != nil {
+		return err
+	}
+	if f != nil {
+		c.copiers = append(c.copiers, f)
+		c.fields = append(c.fields, fieldPair{dst: dst, src: src})
+	}
+
+	return nil
+}
+
+// addPath adds the copier of the nested src field to the nested dst field.
+// If a source pointer along the path is nil, then the destination field is zeroed.
+// Destination pointers along the path are allocated only for a nonzero source value.
+func (c *StructCopier) addPath(dst, src fieldPath) error {
+	dstField, dstOk := dst.field()
+	srcField, srcOk := src.field()
+	if dstOk && srcOk {
+		return c.addField(dstField, srcField)
+	}
+
+	// Fields are copied by the pointers got by the paths.
+	dstField.Offset, srcField.Offset = 0, 0
+	f, err := c.fieldCopier(dstField, srcField)
+	if err != nil || f == nil {
+		return err
+	}
+
+	zero := reflect.Zero(dstField.Type)
+	zeroSrc := reflect.New(srcField.Type).UnsafePointer()
+	size := srcField.Type.Size()
+	omit := c.omitEmpty(dstField, srcField)
+	c.copiers = append(c.copiers, func(dstPtr, srcPtr unsafe.Pointer) {
+		srcPtr = src.get(srcPtr, false)
+		switch {
+		case dstField.HasDefault:
+			// The default value is set for the nil or empty source.
+			if srcPtr == nil {
+				srcPtr = zeroSrc
+			}
+			f(dst.get(dstPtr, true), srcPtr)
+		case srcPtr == nil:
+			if omit {
+				return
+			}
+			if dstPtr = dst.get(dstPtr, false); dstPtr != nil {
+				reflect.NewAt(dstField.Type, dstPtr).Elem().Set(zero)
+			}
+		case isZeroAt(srcPtr, size):
+			if dstPtr = dst.get(dstPtr, false); dstPtr != nil {
+				f(dstPtr, srcPtr)
+			}
+		default:
+			f(dst.get(dstPtr, true), srcPtr)
 		}
+	})
+	c.fields = append(c.fields, fieldPair{dst: dstField, src: srcField})
+
+	return nil
+}
+
+// ambiguous returns the error if more than one field of the struct is matched.
+func ambiguous(typ reflect.Type, fields []cache.Field) error {
+	if len(fields) < 2 {
+		return nil
+	}
+
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
 	}
 
-	if !c.options.Skip {
-		panic(fmt.Errorf(`field «%s» of type «%s» is not assignable to field «%s» of type «%s»`, src.Name, src.Type.String(), dst.Name, dst.Type.String()))
+	return &AmbiguousFieldError{Type: typ, Fields: names}
+}
+
+// checkOptions returns the error if a field of the destination or the source struct has the unknown tag option,
+// so a misspelled option is not ignored silently.
+func checkOptions(dst, src cache.Struct) error {
+	for _, f := range dst.Fields {
+		if len(f.UnknownOptions) > 0 {
+			return fieldError(f.Name, f.Type, nil, unknownOptionError(f.UnknownOptions))
+		}
+	}
+	for _, f := range src.Fields {
+		if len(f.UnknownOptions) > 0 {
+			return fieldError(f.Name, nil, f.Type, unknownOptionError(f.UnknownOptions))
+		}
 	}
 
 	return nil
 }
+
+func unknownOptionError(options []string) error {
+	return fmt.Errorf("%w «%s»", ErrUnknownOption, strings.Join(options, "», «"))
+}
+
+// Copy copies the contents of src into dst. Dst and src each must be a pointer to struct.
+func (c *StructCopier) Copy(dst, src interface{}) {
+	mustCopy(c, dst, src)
+}
+
+// CopyE is like Copy but returns an error instead of panic.
+func (c *StructCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
+}
+
+func (c *StructCopier) copy(dst, src unsafe.Pointer) {
+	if c.before {
+		c.beforeCopy(dst, src)
+	}
+	c.copyFields(dst, src)
+	if c.after {
+		c.afterCopy(dst, src)
+	}
+}
+
+func (c *StructCopier) copyFields(dst, src unsafe.Pointer) {
+	for _, copier := range c.copiers {
+		copier(dst, src)
+	}
+}
+
+func (c *StructCopier) fieldCopier(dst, src cache.Field) (copierFunc, error) {
+	entry := PlanField{Dst: dst.Name, Src: src.Name, DstType: dst.Type, SrcType: src.Type, Strategy: IgnoredStrategy}
+	if dst.ReadOnly || src.WriteOnly {
+		c.plan = append(c.plan, entry)
+		return nil, nil
+	}
+
+	f, strategy, converter, throws, err := c.timeFieldFunc(dst, src)
+	if err == nil && f == nil {
+		f, strategy, converter, throws, err = c.planCopierFunc(dst.Type, src.Type, dst.Offset, src.Offset)
+	}
+	if err != nil {
+		return nil, fieldError(dst.Name, dst.Type, src.Type, err)
+	}
+	if f == nil && !c.options.Skip {
+		return nil, fieldError(dst.Name, dst.Type, src.Type, &UnsupportedTypesError{Dst: dst.Type, Src: src.Type})
+	}
+	if f != nil {
+		if f, err = c.transformed(f, dst.Type, dst.Name, dst.Offset); err != nil {
+			return nil, fieldError(dst.Name, dst.Type, src.Type, err)
+		}
+	}
+	if f != nil && c.omitEmpty(dst, src) {
+		f = omitEmpty(f, src.Type, src.Offset)
+	}
+	if f != nil && dst.HasDefault {
+		if f, err = withDefault(f, dst, src); err != nil {
+			return nil, fieldError(dst.Name, dst.Type, src.Type, err)
+		}
+	}
+
+	if f != nil && throws {
+		f = withPath(f, dst.Name, dst.Type, src.Type)
+		c.fieldsThrow = true
+	}
+
+	entry.Strategy, entry.Converter = strategy, converter
+	c.plan = append(c.plan, entry)
+
+	return f, nil
+}
+
+// omitEmpty reports whether empty values of the src field are not copied.
+func (c *StructCopier) omitEmpty(dst, src cache.Field) bool {
+	return c.options.IgnoreEmpty || dst.OmitEmpty || src.OmitEmpty
+}
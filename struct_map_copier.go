@@ -0,0 +1,284 @@
+package copy
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/gotidy/copy/internal/cache"
+)
+
+// isStringMap checks that type is a map (or a pointer to map) with string keys.
+func isStringMap(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// isEmptyInterface checks that type is interface{}.
+func isEmptyInterface(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && t.NumMethod() == 0
+}
+
+type structToMapField struct {
+	key   reflect.Value
+	value func(src unsafe.Pointer) reflect.Value
+}
+
+// StructToMapCopier fills a destination map with string keys from the source struct.
+// Keys are the names of the struct fields. If elements of the map are of the interface{} type,
+// then nested structs are converted to maps, and slices of structs to slices of maps.
+type StructToMapCopier struct {
+	BaseCopier
+
+	fields      []structToMapField
+	fieldsThrow bool // Values of fields can throw errors.
+}
+
+func NewStructToMapCopier(c *Copiers) *StructToMapCopier {
+	copier := &StructToMapCopier{BaseCopier: NewBaseCopier(c)}
+	return copier
+}
+
+func (c *StructToMapCopier) init(dst, src reflect.Type) error {
+	c.BaseCopier.init(dst, src)
+
+	srcStruct := c.cache.GetByType(src)
+	if err := checkOptions(cache.Struct{}, srcStruct); err != nil {
+		return err
+	}
+
+	for i := 0; i < srcStruct.NumField(); i++ {
+		srcField := srcStruct.Field(i)
+		if srcField.Anonymous || srcField.WriteOnly {
+			continue
+		}
+		f, throws, err := c.fieldValue(srcField)
+		if err != nil {
+			return err
+		}
+		if f != nil {
+			if throws {
+				f = valueWithPath(f, srcField.Name, dst.Elem(), srcField.Type)
+				c.fieldsThrow = true
+			}
+			key := reflect.ValueOf(srcField.Name).Convert(dst.Key())
+			c.fields = append(c.fields, structToMapField{key: key, value: f})
+		}
+	}
+	c.throwing = c.fieldsThrow
+
+	return nil
+}
+
+// valueWithPath is withPath for the functions returning values of the map.
+func valueWithPath(f func(src unsafe.Pointer) reflect.Value, name string, dst, src reflect.Type) func(src unsafe.Pointer) reflect.Value {
+	return func(srcPtr unsafe.Pointer) reflect.Value {
+		defer func() {
+			if r := recover(); r != nil {
+				rethrow(r, name, dst, src)
+			}
+		}()
+		return f(srcPtr)
+	}
+}
+
+func (c *StructToMapCopier) fieldValue(src cache.Field) (func(src unsafe.Pointer) reflect.Value, bool, error) {
+	offset := src.Offset
+
+	if isEmptyInterface(c.dstType.Elem()) {
+		f, throws, err := c.interfaceValue(src.Type)
+		if err != nil {
+			return nil, false, fieldError(src.Name, c.dstType.Elem(), src.Type, err)
+		}
+		return func(srcPtr unsafe.Pointer) reflect.Value {
+			return f(unsafe.Pointer(uintptr(srcPtr) + offset))
+		}, throws, nil
+	}
+
+	elemType := c.dstType.Elem()
+	f, throws, err := c.getCopierFunc(elemType, src.Type, 0, offset)
+	if err != nil {
+		return nil, false, fieldError(src.Name, elemType, src.Type, err)
+	}
+	if f != nil {
+		return func(srcPtr unsafe.Pointer) reflect.Value {
+			value := reflect.New(elemType)
+			f(unsafe.Pointer(value.Pointer()), srcPtr)
+			return value.Elem()
+		}, throws, nil
+	}
+
+	if !c.options.Skip {
+		return nil, false, fieldError(src.Name, elemType, src.Type, &UnsupportedTypesError{Dst: elemType, Src: src.Type})
+	}
+
+	return nil, false, nil
+}
+
+// interfaceValue returns the function that converts value of the type typ to a value that will be stored in the map
+// and reports whether the function can throw an error.
+func (c *StructToMapCopier) interfaceValue(typ reflect.Type) (func(src unsafe.Pointer) reflect.Value, bool, error) {
+	switch {
+	case typ.Kind() == reflect.Struct && c.cache.GetByType(typ).NumField() > 0:
+		copier, err := c.get(c.dstType, typ)
+		if err != nil {
+			return nil, false, err
+		}
+		return func(srcPtr unsafe.Pointer) reflect.Value {
+			value := reflect.New(c.dstType)
+			copier.copy(unsafe.Pointer(value.Pointer()), srcPtr)
+			return value.Elem()
+		}, copier.throws(), nil
+	case typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct && c.cache.GetByType(typ.Elem()).NumField() > 0:
+		elem, throws, err := c.interfaceValue(typ.Elem())
+		if err != nil {
+			return nil, false, err
+		}
+		zero := reflect.Zero(c.dstType.Elem())
+		return func(srcPtr unsafe.Pointer) reflect.Value {
+			ptr := *(*unsafe.Pointer)(srcPtr)
+			if ptr == nil {
+				return zero
+			}
+			return elem(ptr)
+		}, throws, nil
+	case typ.Kind() == reflect.Slice && isStructOrStructPtr(typ.Elem()):
+		elem, throws, err := c.interfaceValue(typ.Elem())
+		if err != nil {
+			return nil, false, err
+		}
+		size := typ.Elem().Size()
+		sliceType := reflect.SliceOf(c.dstType.Elem())
+		return func(srcPtr unsafe.Pointer) reflect.Value {
+			src := sliceAt(srcPtr, size)
+			if src.data == nil {
+				return reflect.Zero(sliceType)
+			}
+			value := reflect.MakeSlice(sliceType, src.Len, src.Len)
+			for i := 0; i < src.Len; i++ {
+				value.Index(i).Set(elem(src.Index(i)))
+			}
+			return value
+		}, throws, nil
+	}
+
+	return func(srcPtr unsafe.Pointer) reflect.Value {
+		return reflect.NewAt(typ, srcPtr).Elem()
+	}, false, nil
+}
+
+func isStructOrStructPtr(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// Copy copies the contents of src into dst. Dst must be a pointer to map and src must be a pointer to struct.
+func (c *StructToMapCopier) Copy(dst, src interface{}) {
+	mustCopy(c, dst, src)
+}
+
+// CopyE is like Copy but returns an error instead of panic.
+func (c *StructToMapCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
+}
+
+func (c *StructToMapCopier) copy(dst, src unsafe.Pointer) {
+	m := reflect.MakeMapWithSize(c.dstType, len(c.fields))
+	for _, f := range c.fields {
+		m.SetMapIndex(f.key, f.value(src))
+	}
+	reflect.NewAt(c.dstType, dst).Elem().Set(m)
+}
+
+type mapToStructField struct {
+	key    reflect.Value
+	copier copierFunc
+}
+
+// MapToStructCopier fills a destination struct from the source map with string keys.
+// Keys are the names of the struct fields, fields without keys in the map stay untouched.
+type MapToStructCopier struct {
+	BaseCopier
+
+	fields      []mapToStructField
+	fieldsThrow bool // Copiers of fields can throw errors.
+}
+
+func NewMapToStructCopier(c *Copiers) *MapToStructCopier {
+	copier := &MapToStructCopier{BaseCopier: NewBaseCopier(c)}
+	return copier
+}
+
+func (c *MapToStructCopier) init(dst, src reflect.Type) error {
+	c.BaseCopier.init(dst, src)
+
+	dstStruct := c.cache.GetByType(dst)
+	if err := checkOptions(dstStruct, cache.Struct{}); err != nil {
+		return err
+	}
+
+	for i := 0; i < dstStruct.NumField(); i++ {
+		dstField := dstStruct.Field(i)
+		if dstField.Anonymous || dstField.ReadOnly {
+			continue
+		}
+		f, err := c.fieldCopier(dstField)
+		if err != nil {
+			return err
+		}
+		if f != nil {
+			key := reflect.ValueOf(dstField.Name).Convert(src.Key())
+			c.fields = append(c.fields, mapToStructField{key: key, copier: f})
+		}
+	}
+	c.throwing = c.fieldsThrow
+
+	return nil
+}
+
+func (c *MapToStructCopier) fieldCopier(dst cache.Field) (copierFunc, error) {
+	f, throws, err := c.getCopierFunc(dst.Type, c.srcType.Elem(), dst.Offset, 0)
+	if err != nil {
+		return nil, fieldError(dst.Name, dst.Type, c.srcType.Elem(), err)
+	}
+	if f == nil && !c.options.Skip {
+		return nil, fieldError(dst.Name, dst.Type, c.srcType.Elem(), &UnsupportedTypesError{Dst: dst.Type, Src: c.srcType.Elem()})
+	}
+	if f != nil && throws {
+		f = withPath(f, dst.Name, dst.Type, c.srcType.Elem())
+		c.fieldsThrow = true
+	}
+
+	return f, nil
+}
+
+// Copy copies the contents of src into dst. Dst must be a pointer to struct and src must be a pointer to map.
+func (c *MapToStructCopier) Copy(dst, src interface{}) {
+	mustCopy(c, dst, src)
+}
+
+// CopyE is like Copy but returns an error instead of panic.
+func (c *MapToStructCopier) CopyE(dst, src interface{}) error {
+	return copyE(c, dst, src)
+}
+
+func (c *MapToStructCopier) copy(dst, src unsafe.Pointer) {
+	m := reflect.NewAt(c.srcType, src).Elem()
+	if m.Len() == 0 {
+		return
+	}
+
+	// Map elements are not addressable, so each of them is copied to the temporary value.
+	elem := reflect.New(c.srcType.Elem())
+	for _, f := range c.fields {
+		value := m.MapIndex(f.key)
+		if !value.IsValid() {
+			continue
+		}
+		elem.Elem().Set(value)
+		f.copier(dst, unsafe.Pointer(elem.Pointer()))
+	}
+}
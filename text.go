@@ -0,0 +1,127 @@
+package copy
+
+import (
+	"encoding"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isText reports whether values of the type are strings or byte slices.
+func isText(t reflect.Type) bool {
+	return t.Kind() == reflect.String || isBytes(t)
+}
+
+// isBytes reports whether values of the type are byte slices.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// textFunc returns the function that copies values implementing encoding.TextMarshaler to strings or byte slices
+// and strings or byte slices to values implementing encoding.TextUnmarshaler, including values with the methods
+// of pointer receivers and pointers to such values. If the types are not supported, then nil is returned.
+func textFunc(dst, src reflect.Type) copierFunc {
+	// Byte slices are copied as is, like net.IP to []byte.
+	if dst == src || (isBytes(dst) && isBytes(src)) {
+		return nil
+	}
+
+	if isText(dst) {
+		if marshal := textMarshaler(src); marshal != nil {
+			return func(dstPtr, srcPtr unsafe.Pointer) {
+				setText(reflect.NewAt(dst, dstPtr).Elem(), marshal(srcPtr))
+			}
+		}
+	}
+
+	if isText(src) {
+		if unmarshal := textUnmarshaler(dst); unmarshal != nil {
+			return func(dstPtr, srcPtr unsafe.Pointer) {
+				unmarshal(dstPtr, getText(reflect.NewAt(src, srcPtr).Elem()))
+			}
+		}
+	}
+
+	return nil
+}
+
+// textMarshaler returns the function marshaling the value of the type at the pointer,
+// a nil pointer is marshaled to the empty text. If the type does not implement encoding.TextMarshaler, then nil is returned.
+func textMarshaler(t reflect.Type) func(ptr unsafe.Pointer) []byte {
+	marshal := func(v reflect.Value) []byte {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			throw(err)
+		}
+		return text
+	}
+
+	switch {
+	case t.Kind() == reflect.Ptr && t.Implements(textMarshalerType):
+		return func(ptr unsafe.Pointer) []byte {
+			v := reflect.NewAt(t, ptr).Elem()
+			if v.IsNil() {
+				return nil
+			}
+			return marshal(v)
+		}
+	case reflect.PtrTo(t).Implements(textMarshalerType):
+		return func(ptr unsafe.Pointer) []byte {
+			return marshal(reflect.NewAt(t, ptr))
+		}
+	}
+
+	return nil
+}
+
+// textUnmarshaler returns the function unmarshaling the text to the value of the type at the pointer.
+// A nil pointer is set to the new value, the empty text sets the pointer to nil. If the type does not implement encoding.TextUnmarshaler, then nil is returned.
+func textUnmarshaler(t reflect.Type) func(ptr unsafe.Pointer, text []byte) {
+	unmarshal := func(v reflect.Value, text []byte) {
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+			throw(err)
+		}
+	}
+
+	switch {
+	case t.Kind() == reflect.Ptr && t.Implements(textUnmarshalerType):
+		return func(ptr unsafe.Pointer, text []byte) {
+			v := reflect.NewAt(t, ptr).Elem()
+			if len(text) == 0 {
+				v.Set(reflect.Zero(t))
+				return
+			}
+			if v.IsNil() {
+				v.Set(reflect.New(t.Elem()))
+			}
+			unmarshal(v, text)
+		}
+	case reflect.PtrTo(t).Implements(textUnmarshalerType):
+		return func(ptr unsafe.Pointer, text []byte) {
+			unmarshal(reflect.NewAt(t, ptr), text)
+		}
+	}
+
+	return nil
+}
+
+// setText sets the text to the string or the byte slice value.
+func setText(v reflect.Value, text []byte) {
+	if v.Kind() == reflect.String {
+		v.SetString(string(text))
+		return
+	}
+	v.Set(reflect.ValueOf(text).Convert(v.Type()))
+}
+
+// getText returns the text of the string or the byte slice value.
+func getText(v reflect.Value) []byte {
+	if v.Kind() == reflect.String {
+		return []byte(v.String())
+	}
+	return v.Bytes()
+}
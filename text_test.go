@@ -0,0 +1,129 @@
+package copy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+type textColor int
+
+func (c textColor) MarshalText() ([]byte, error) {
+	switch c {
+	case 1:
+		return []byte("red"), nil
+	case 2:
+		return []byte("green"), nil
+	}
+	return nil, fmt.Errorf("unknown color %d", int(c))
+}
+
+func (c *textColor) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "red":
+		*c = 1
+	case "green":
+		*c = 2
+	default:
+		return fmt.Errorf("unknown color %q", text)
+	}
+	return nil
+}
+
+type textID struct {
+	hi, lo uint32
+}
+
+func (id *textID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d-%d", id.hi, id.lo)), nil
+}
+
+func (id *textID) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d-%d", &id.hi, &id.lo)
+	return err
+}
+
+type textLevel string
+
+func (l textLevel) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(l))), nil
+}
+
+func (l *textLevel) UnmarshalText(text []byte) error {
+	*l = textLevel(strings.ToLower(string(text)))
+	return nil
+}
+
+func TestTextMarshaler(t *testing.T) {
+	type Entity struct {
+		ID     textID
+		Parent *textID
+		Owner  *textID
+		Color  textColor
+		Shade  *textColor
+		IP     net.IP
+	}
+	type DTO struct {
+		ID     string
+		Parent []byte
+		Owner  string
+		Color  string
+		Shade  string
+		IP     string
+	}
+
+	src := Entity{ID: textID{1, 2}, Parent: &textID{3, 4}, Color: 1, IP: net.IPv4(10, 0, 0, 1)}
+
+	dto := DTO{Owner: "owner", Shade: "shade"}
+	Copy(&dto, &src)
+	equal(t, dto, DTO{ID: "1-2", Parent: []byte("3-4"), Color: "red", IP: "10.0.0.1"})
+
+	entity := Entity{}
+	Copy(&entity, &DTO{ID: "5-6", Parent: []byte("7-8"), Owner: "9-10", Color: "green", Shade: "red", IP: "192.168.0.1"})
+	shade := textColor(1)
+	equal(t, entity, Entity{ID: textID{5, 6}, Parent: &textID{7, 8}, Owner: &textID{9, 10}, Color: 2, Shade: &shade, IP: net.IPv4(192, 168, 0, 1)})
+
+	Copy(&entity, &DTO{ID: "1-2", Color: "red", IP: "::1"})
+	if entity.Parent != nil || entity.Owner != nil || entity.Shade != nil {
+		t.Errorf("the empty text must set nil pointers, got %v", entity)
+	}
+
+	err := CopyE(&entity, &DTO{ID: "1-2", Owner: "1-2", Color: "blue", Shade: "red", IP: "::1"})
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Color" || !strings.Contains(err.Error(), `unknown color "blue"`) {
+		t.Errorf("want the unmarshal error of «Color», got %v", err)
+	}
+
+	err = CopyE(&dto, &Entity{Color: 3})
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Color" {
+		t.Errorf("want the marshal error of «Color», got %v", err)
+	}
+
+	plan := Explain(&dto, &src)
+	for _, f := range plan.Fields {
+		if f.Strategy != TextStrategy {
+			t.Errorf("want the text strategy of «%s», got %s", f.Dst, f.Strategy)
+		}
+	}
+
+	type Host struct {
+		IP    net.IP
+		Level textLevel
+	}
+	type HostDTO struct {
+		IP    []byte
+		Level string
+	}
+
+	// Byte slices are copied as is, text methods are not called.
+	host := Host{IP: net.IP{10, 0, 0, 1}, Level: "abc"}
+	hostDTO := HostDTO{}
+	Copy(&hostDTO, &host)
+	equal(t, hostDTO, HostDTO{IP: []byte{10, 0, 0, 1}, Level: "ABC"})
+
+	host = Host{}
+	Copy(&host, &hostDTO)
+	equal(t, host, Host{IP: net.IP{10, 0, 0, 1}, Level: "abc"})
+}
@@ -0,0 +1,197 @@
+package copy
+
+import (
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/gotidy/copy/internal/cache"
+)
+
+// Special layouts converting times to and from the Unix time.
+const (
+	// UnixLayout converts times to seconds since the Unix epoch.
+	UnixLayout = "unix"
+	// UnixMilliLayout converts times to milliseconds since the Unix epoch.
+	UnixMilliLayout = "unixmilli"
+)
+
+// layouts are the layouts of the time package, they can be set by the names in tags, so layouts with commas can be used.
+var layouts = map[string]string{
+	"Layout":      time.Layout,
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    "2006-01-02 15:04:05",
+	"DateOnly":    "2006-01-02",
+	"TimeOnly":    "15:04:05",
+}
+
+// TimeFormat sets the layout and the location of times converted to and from strings and integers.
+// The layout is the layout of the time package, the name of the time package constant like «RFC1123»,
+// UnixLayout or UnixMilliLayout. Integers are seconds since the Unix epoch unless the layout is UnixMilliLayout.
+// Times are converted to the location before formatting, parsed times are in the location.
+// If the location is nil, then times are formatted in their locations and parsed as by time.Parse.
+// Fields can override the format by the «layout=value» and «tz=name» tag options.
+//
+//	c := copy.New(copy.TimeFormat("DateOnly", time.UTC))
+func TimeFormat(layout string, loc *time.Location) Option {
+	return func(o *Options) {
+		o.TimeLayout = layout
+		o.TimeLocation = loc
+		converters(timeConverters(newTimeFormat(layout, loc)))(o)
+	}
+}
+
+// timeFormat is the format of times converted to and from strings and integers.
+type timeFormat struct {
+	layout string
+	loc    *time.Location
+}
+
+func newTimeFormat(layout string, loc *time.Location) timeFormat {
+	if l, ok := layouts[layout]; ok {
+		layout = l
+	}
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	return timeFormat{layout: layout, loc: loc}
+}
+
+func (f timeFormat) in(t time.Time) time.Time {
+	if f.loc != nil {
+		return t.In(f.loc)
+	}
+	return t
+}
+
+func (f timeFormat) unix(sec, nsec int64) time.Time {
+	t := time.Unix(sec, nsec)
+	if f.loc != nil {
+		return t.In(f.loc)
+	}
+	return t
+}
+
+func (f timeFormat) toInt(t time.Time) (int64, error) {
+	if f.layout == UnixMilliLayout {
+		return t.UnixMilli(), nil
+	}
+	return t.Unix(), nil
+}
+
+func (f timeFormat) fromInt(i int64) (time.Time, error) {
+	if f.layout == UnixMilliLayout {
+		return f.unix(0, i*int64(time.Millisecond)), nil
+	}
+	return f.unix(i, 0), nil
+}
+
+func (f timeFormat) format(t time.Time) (string, error) {
+	if f.layout == UnixLayout || f.layout == UnixMilliLayout {
+		i, err := f.toInt(t)
+		return strconv.FormatInt(i, 10), err
+	}
+	return f.in(t).Format(f.layout), nil
+}
+
+func (f timeFormat) parse(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if f.layout == UnixLayout || f.layout == UnixMilliLayout {
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return f.fromInt(i)
+	}
+	if f.loc != nil {
+		return time.ParseInLocation(f.layout, s, f.loc)
+	}
+	return time.Parse(f.layout, s)
+}
+
+// withField returns the format overridden by the «layout» and «tz» tag options of the field.
+func (f timeFormat) withField(field cache.Field) (timeFormat, error) {
+	if field.Layout != "" {
+		f.layout = newTimeFormat(field.Layout, nil).layout
+	}
+	if field.TimeZone != "" {
+		loc, err := time.LoadLocation(field.TimeZone)
+		if err != nil {
+			return f, err
+		}
+		f.loc = loc
+	}
+
+	return f, nil
+}
+
+// timeConverters returns the converters of times to strings and integers and vice versa, and the converters
+// of durations to strings and vice versa, including pointers.
+func timeConverters(f timeFormat) []converterFunc {
+	funcs := append(converterFuncs(f.format), converterFuncs(f.parse)...)
+	funcs = append(funcs, converterFuncs(f.toInt)...)
+	funcs = append(funcs, converterFuncs(f.fromInt)...)
+	funcs = append(funcs, converterFuncs(formatDuration)...)
+	funcs = append(funcs, converterFuncs(parseDuration)...)
+
+	return funcs
+}
+
+func formatDuration(d time.Duration) (string, error) {
+	return d.String(), nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// timeFieldFunc returns the function converting the field by the time format overridden by the tag options of the fields.
+// It returns nil if the fields have no such options or the types are not converted by the format.
+func (c *StructCopier) timeFieldFunc(dst, src cache.Field) (copierFunc, Strategy, string, bool, error) {
+	if dst.Layout == "" && dst.TimeZone == "" && src.Layout == "" && src.TimeZone == "" {
+		return nil, SkippedStrategy, "", false, nil
+	}
+
+	f, err := newTimeFormat(c.options.TimeLayout, c.options.TimeLocation).withField(src)
+	if err == nil {
+		f, err = f.withField(dst)
+	}
+	if err != nil {
+		return nil, SkippedStrategy, "", false, err
+	}
+
+	for _, converter := range timeConverters(f) {
+		if converter.dst == dst.Type && converter.src == src.Type {
+			convert, dstOffset, srcOffset := converter.f, dst.Offset, src.Offset
+			return func(dstPtr, srcPtr unsafe.Pointer) {
+				convert(unsafe.Pointer(uintptr(dstPtr)+dstOffset), unsafe.Pointer(uintptr(srcPtr)+srcOffset))
+			}, FuncStrategy, converter.name, true, nil
+		}
+	}
+
+	return nil, SkippedStrategy, "", false, nil
+}
+
+func init() {
+	register(timeConverters(newTimeFormat(time.RFC3339, nil)))
+}
@@ -0,0 +1,98 @@
+package copy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestTimeConverters(t *testing.T) {
+	type Event struct {
+		CreatedAt time.Time
+		UpdatedAt *time.Time
+		DeletedAt *time.Time
+		StartedAt time.Time
+		Timeout   time.Duration
+	}
+	type EventDTO struct {
+		CreatedAt string
+		UpdatedAt int64
+		DeletedAt *string
+		StartedAt *int64
+		Timeout   string
+	}
+
+	created := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	src := Event{CreatedAt: created, UpdatedAt: &created, StartedAt: created, Timeout: 90 * time.Second}
+
+	dto := EventDTO{}
+	Copy(&dto, &src)
+	equal(t, dto, EventDTO{CreatedAt: "2021-01-02T03:04:05Z", UpdatedAt: created.Unix(), StartedAt: ptr.Int64(created.Unix()), Timeout: "1m30s"})
+
+	event := Event{}
+	Copy(&event, &dto)
+	if !event.CreatedAt.Equal(created) || !event.UpdatedAt.Equal(created) || event.DeletedAt != nil || !event.StartedAt.Equal(created) || event.Timeout != src.Timeout {
+		t.Errorf("want %v, got %v", src, event)
+	}
+
+	err := CopyE(&event, &EventDTO{CreatedAt: "yesterday"})
+	var fieldErr *FieldError
+	var parseErr *time.ParseError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "CreatedAt" || !errors.As(err, &parseErr) {
+		t.Errorf("want the parse error of «CreatedAt», got %v", err)
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	type Event struct {
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		StartedAt time.Time
+		Day       time.Time
+	}
+	type EventDTO struct {
+		CreatedAt string
+		UpdatedAt int64  `copy:",layout=unixmilli"`
+		StartedAt string `copy:",layout=RFC1123,tz=UTC"`
+		Day       string `copy:",layout=2006-01-02"`
+	}
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	created := time.Date(2021, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	src := Event{CreatedAt: created, UpdatedAt: created, StartedAt: created.In(moscow), Day: created}
+
+	c := New(Tag("copy"), TimeFormat("DateTime", moscow))
+
+	dto := EventDTO{}
+	c.Copy(&dto, &src)
+	equal(t, dto, EventDTO{
+		CreatedAt: "2021-01-02 06:04:05",
+		UpdatedAt: created.UnixMilli(),
+		StartedAt: "Sat, 02 Jan 2021 03:04:05 UTC",
+		Day:       "2021-01-02",
+	})
+
+	event := Event{}
+	c.Copy(&event, &dto)
+	if !event.CreatedAt.Equal(created.Truncate(time.Second)) || event.CreatedAt.Location() != moscow {
+		t.Errorf("want %v in the location, got %v", created, event.CreatedAt)
+	}
+	if !event.UpdatedAt.Equal(created) {
+		t.Errorf("want %v, got %v", created, event.UpdatedAt)
+	}
+	if !event.StartedAt.Equal(created.Truncate(time.Second)) {
+		t.Errorf("want %v, got %v", created, event.StartedAt)
+	}
+	if want := time.Date(2021, 1, 2, 0, 0, 0, 0, moscow); !event.Day.Equal(want) {
+		t.Errorf("want %v, got %v", want, event.Day)
+	}
+
+	type BadZone struct {
+		CreatedAt string `copy:",tz=Nowhere/Never"`
+	}
+	if err := New(Tag("copy")).PrepareE(&BadZone{}, &Event{}); err == nil {
+		t.Error("unknown time zone must cause the error")
+	}
+}
@@ -0,0 +1,71 @@
+package copy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+type transformKey struct {
+	Struct reflect.Type
+	Field  string
+}
+
+// transform changes the value of the field at ptr.
+type transform struct {
+	typ reflect.Type
+	f   func(ptr unsafe.Pointer)
+}
+
+// Transform sets the function that changes the value of the destination struct field after copying.
+// Dst must be a pointer to struct, the field is the field name, the name set by the tag or the dotted path
+// of the mapped nested field. The type of the function argument must be the type of the field.
+//
+//	c := copy.New(copy.Transform(&Employee{}, "Email", func(v string) string {
+//		return strings.ToLower(strings.TrimSpace(v))
+//	}))
+func Transform[T any](dst interface{}, field string, f func(v T) T) Option {
+	key := transformKey{Struct: reflect.TypeOf(dst).Elem(), Field: field}
+	t := transform{
+		typ: reflect.TypeOf((*T)(nil)).Elem(),
+		f: func(ptr unsafe.Pointer) {
+			*(*T)(ptr) = f(*(*T)(ptr))
+		},
+	}
+
+	return func(o *Options) {
+		if o.transforms == nil {
+			o.transforms = make(map[transformKey]transform)
+		}
+		o.transforms[key] = t
+	}
+}
+
+// checkTransforms checks that the fields of the transforms set for the dst struct exist.
+func (c *StructCopier) checkTransforms(dst, src reflect.Type) error {
+	for key := range c.options.transforms {
+		if key.Struct != dst {
+			continue
+		}
+		if _, ok := c.resolvePath(dst, key.Field); !ok {
+			return fieldError(key.Field, dst, src, ErrFieldNotFound)
+		}
+	}
+
+	return nil
+}
+
+// transformed wraps the copier function of the field, so the copied value is transformed.
+func (c *StructCopier) transformed(f copierFunc, dst reflect.Type, field string, offset uintptr) (copierFunc, error) {
+	t, ok := c.options.transforms[transformKey{Struct: c.dstType, Field: field}]
+	if !ok {
+		return f, nil
+	}
+	if t.typ != dst {
+		return nil, &TypeMismatchError{Arg: "transform", Expected: dst.String(), Actual: t.typ.String()}
+	}
+
+	return func(dstPtr, srcPtr unsafe.Pointer) {
+		f(dstPtr, srcPtr)
+		t.f(unsafe.Pointer(uintptr(dstPtr) + offset))
+	}, nil
+}
@@ -0,0 +1,67 @@
+package copy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	type testStruct1 struct {
+		Email      string
+		CardNumber string
+		Price      float64
+		Cost       float64
+	}
+	type testStruct2 struct {
+		Email      string
+		CardNumber string
+		Price      float64
+		Cost       float64
+	}
+
+	c := New(
+		Transform(&testStruct2{}, "Email", func(v string) string {
+			return strings.ToLower(strings.TrimSpace(v))
+		}),
+		Transform(&testStruct2{}, "CardNumber", func(v string) string {
+			return strings.Repeat("*", len(v)-4) + v[len(v)-4:]
+		}),
+		Transform(&testStruct2{}, "Price", func(v float64) float64 {
+			return v / 100
+		}),
+	)
+
+	dst := testStruct2{}
+	c.Copy(&dst, &testStruct1{Email: " John@Example.COM ", CardNumber: "1234567812345678", Price: 1250, Cost: 1250})
+	equal(t, dst, testStruct2{Email: "john@example.com", CardNumber: "************5678", Price: 12.5, Cost: 1250})
+
+	// Other pairs with the same destination are transformed too, other destinations are not.
+	src := testStruct1{}
+	c.Copy(&src, &testStruct1{Email: " John@Example.COM "})
+	equal(t, src, testStruct1{Email: " John@Example.COM "})
+}
+
+func TestTransform_TypeMismatch(t *testing.T) {
+	type testStruct struct {
+		Price float64
+	}
+
+	c := New(Transform(&testStruct{}, "Price", func(v int) int { return v }))
+	err := c.PrepareE(&testStruct{}, &testStruct{})
+	var mismatch *TypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Errorf("want TypeMismatchError, got %v", err)
+	}
+}
+
+func TestTransform_FieldNotFound(t *testing.T) {
+	type testStruct struct {
+		Email string
+	}
+
+	c := New(Transform(&testStruct{}, "Emial", func(v string) string { return v }))
+	if err := c.PrepareE(&testStruct{}, &testStruct{}); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("want ErrFieldNotFound, got %v", err)
+	}
+}
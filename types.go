@@ -9,7 +9,7 @@ const (
 	StructValue    ValueKind = 1
 	SliceValue     ValueKind = 2
 	MapValue       ValueKind = 3
-	PtrValue       ValueKind = 0b10
+	PtrValue       ValueKind = 0b100
 	StructPtrValue ValueKind = StructValue + PtrValue
 	SlicePtrValue  ValueKind = SliceValue + PtrValue
 	MapPtrValue    ValueKind = MapValue + PtrValue
@@ -26,8 +26,8 @@ func getValueKind(t reflect.Type) ValueKind {
 		kind += StructValue
 	case k == reflect.Slice:
 		kind += SliceValue
-	// case k == reflect.Map && t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.Interface:
-	// 	kind += MapValue
+	case k == reflect.Map:
+		kind += MapValue
 	default:
 		return UnknownKind
 	}
@@ -55,6 +55,30 @@ func getCopier(c *Copiers, dst, src reflect.Type) internalCopier {
 		return NewPValueToValueCopier(c)
 	case srcKind == SlicePtrValue && dstKind == SlicePtrValue:
 		return NewPValueToPValueCopier(c)
+	case srcKind == MapValue && dstKind == MapValue:
+		return NewMapCopier(c)
+	case srcKind == MapValue && dstKind == MapPtrValue:
+		return NewValueToPValueCopier(c)
+	case srcKind == MapPtrValue && dstKind == MapValue:
+		return NewPValueToValueCopier(c)
+	case srcKind == MapPtrValue && dstKind == MapPtrValue:
+		return NewPValueToPValueCopier(c)
+	case srcKind == StructValue && dstKind == MapValue && isStringMap(dst):
+		return NewStructToMapCopier(c)
+	case srcKind == StructValue && dstKind == MapPtrValue && isStringMap(dst):
+		return NewValueToPValueCopier(c)
+	case srcKind == StructPtrValue && dstKind == MapValue && isStringMap(dst):
+		return NewPValueToValueCopier(c)
+	case srcKind == StructPtrValue && dstKind == MapPtrValue && isStringMap(dst):
+		return NewPValueToPValueCopier(c)
+	case srcKind == MapValue && dstKind == StructValue && isStringMap(src):
+		return NewMapToStructCopier(c)
+	case srcKind == MapValue && dstKind == StructPtrValue && isStringMap(src):
+		return NewValueToPValueCopier(c)
+	case srcKind == MapPtrValue && dstKind == StructValue && isStringMap(src):
+		return NewPValueToValueCopier(c)
+	case srcKind == MapPtrValue && dstKind == StructPtrValue && isStringMap(src):
+		return NewPValueToPValueCopier(c)
 	}
 	return nil
 }